@@ -0,0 +1,68 @@
+package mysqlctl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultHost is the host of accounts referred to by a bare username.
+const DefaultHost = "%"
+
+// Account is a MySQL account, identified by user name and host
+// ('user'@'host').
+type Account struct {
+	User string
+	Host string
+}
+
+// NewAccount returns the account of the given user with the DefaultHost.
+func NewAccount(username string) Account {
+	return Account{User: username, Host: DefaultHost}
+}
+
+// String returns the account in the 'user'@'host' notation.
+func (a Account) String() string {
+	return "'" + strings.ReplaceAll(a.User, "'", "''") + "'@'" + strings.ReplaceAll(a.Host, "'", "''") + "'"
+}
+
+// Credentials are the account and plain text password of a user.
+type Credentials struct {
+	Account  Account
+	Password string
+}
+
+// AccountStatus is the locking and password expiration state of an account.
+type AccountStatus struct {
+	Locked bool
+	// PasswordExpired is true if the password was expired with PASSWORD
+	// EXPIRE or is older than its lifetime.
+	PasswordExpired     bool
+	PasswordLastChanged time.Time
+	// PasswordLifetime is the number of days a password stays valid, nil when
+	// the server's default_password_lifetime applies and 0 when it never
+	// expires.
+	PasswordLifetime *int
+}
+
+func validateAccount(account Account) error {
+	err := validateUsername(account.User)
+	if err != nil {
+		return err
+	}
+
+	if account.Host == "" {
+		return fmt.Errorf("host cannot be empty")
+	}
+	return nil
+}
+
+func filterAccounts(accounts []Account) []Account {
+	var filtered []Account
+	for _, account := range accounts {
+		if !contains(baseUsers, account.User) {
+			filtered = append(filtered, account)
+		}
+	}
+	return filtered
+}
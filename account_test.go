@@ -0,0 +1,21 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccount(t *testing.T) {
+	a := NewAccount(testUser)
+	assert.Equal(t, Account{User: testUser, Host: "%"}, a)
+	assert.Equal(t, "'test-user'@'%'", a.String())
+}
+
+func Test_validateAccount(t *testing.T) {
+	assert.NoError(t, validateAccount(NewAccount(testUser)))
+	assert.NoError(t, validateAccount(Account{User: testUser, Host: "localhost"}))
+	assert.Error(t, validateAccount(Account{User: testUser}))
+	assert.Error(t, validateAccount(NewAccount("")))
+	assert.Error(t, validateAccount(Account{User: "root", Host: "localhost"}))
+}
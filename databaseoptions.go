@@ -0,0 +1,85 @@
+package mysqlctl
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DatabaseOption configures the defaults of a database when it is created or
+// altered.
+type DatabaseOption func(*databaseOptions)
+
+type databaseOptions struct {
+	charset    string
+	collation  string
+	encryption *bool
+}
+
+// WithCharset returns a DatabaseOption that sets the default character set of
+// the database, e.g. "utf8mb4".
+func WithCharset(charset string) DatabaseOption {
+	return func(o *databaseOptions) {
+		o.charset = charset
+	}
+}
+
+// WithCollation returns a DatabaseOption that sets the default collation of
+// the database, e.g. "utf8mb4_0900_ai_ci".
+func WithCollation(collation string) DatabaseOption {
+	return func(o *databaseOptions) {
+		o.collation = collation
+	}
+}
+
+// WithEncryption returns a DatabaseOption that sets whether tables created in
+// the database are encrypted by default (MySQL 8.0.16 and later).
+func WithEncryption(enabled bool) DatabaseOption {
+	return func(o *databaseOptions) {
+		o.encryption = &enabled
+	}
+}
+
+func newDatabaseOptions(opts []DatabaseOption) databaseOptions {
+	var o databaseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// empty reports whether no option is set.
+func (o databaseOptions) empty() bool {
+	return o.charset == "" && o.collation == "" && o.encryption == nil
+}
+
+var charsetNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+func (o databaseOptions) validate() error {
+	if o.charset != "" && !charsetNameRegexp.MatchString(o.charset) {
+		return fmt.Errorf("invalid character set %q", o.charset)
+	}
+	if o.collation != "" && !charsetNameRegexp.MatchString(o.collation) {
+		return fmt.Errorf("invalid collation %q", o.collation)
+	}
+	return nil
+}
+
+// clauses returns the options of CREATE DATABASE and ALTER DATABASE, each
+// preceded by a space.
+func (o databaseOptions) clauses() string {
+	var clauses string
+	if o.charset != "" {
+		clauses += " CHARACTER SET " + o.charset
+	}
+	if o.collation != "" {
+		clauses += " COLLATE " + o.collation
+	}
+	if o.encryption != nil {
+		if *o.encryption {
+			clauses += " DEFAULT ENCRYPTION 'Y'"
+		} else {
+			clauses += " DEFAULT ENCRYPTION 'N'"
+		}
+	}
+	return clauses
+}
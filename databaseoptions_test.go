@@ -0,0 +1,25 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_databaseOptions(t *testing.T) {
+	o := newDatabaseOptions(nil)
+	assert.True(t, o.empty())
+	assert.Equal(t, "", o.clauses())
+
+	o = newDatabaseOptions([]DatabaseOption{WithCharset("utf8mb4"), WithCollation("utf8mb4_bin"), WithEncryption(true)})
+	assert.False(t, o.empty())
+	assert.NoError(t, o.validate())
+	assert.Equal(t, " CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT ENCRYPTION 'Y'", o.clauses())
+
+	o = newDatabaseOptions([]DatabaseOption{WithEncryption(false)})
+	assert.Equal(t, " DEFAULT ENCRYPTION 'N'", o.clauses())
+
+	for _, opt := range []DatabaseOption{WithCharset("utf8mb4; DROP DATABASE mysql"), WithCollation("utf8mb4 bin"), WithCharset("'latin1'")} {
+		assert.Error(t, newDatabaseOptions([]DatabaseOption{opt}).validate())
+	}
+}
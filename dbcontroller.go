@@ -1,29 +1,73 @@
 package mysqlctl
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"strings"
+	"math"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
 type DBController interface {
 	// CreateDatabase creates a database.
-	CreateDatabase(dbName string) error
+	CreateDatabase(dbName string, opts ...DatabaseOption) error
+	// AlterDatabase changes the defaults of a database.
+	AlterDatabase(dbName string, opts ...DatabaseOption) error
+	// DatabaseInfo returns the defaults of a database.
+	DatabaseInfo(dbName string) (DatabaseInfo, error)
 	// DeleteDatabase deletes a database.
 	DeleteDatabase(dbName string) error
+	// RenameDatabase renames a database.
+	RenameDatabase(oldName, newName string) error
 	// ListDatabases returns a list of databases.
 	ListDatabases() ([]string, error)
 	// DatabaseExists returns true if the database exists.
 	DatabaseExists(dbName string) (bool, error)
 	// Size returns the size of the database in Bytes.
 	Size(dbName string) (int, error)
+	// SizeAll returns the size of every database in Bytes.
+	SizeAll() (map[string]int64, error)
 	// Tables returns a list of tables in the database.
 	Tables(dbName string) ([]string, error)
+	// TableStats returns the statistics of every table in the database.
+	TableStats(dbName string) ([]TableStats, error)
+	// DatabaseUsers returns every account with any privilege on the database.
+	DatabaseUsers(dbName string) ([]DatabaseAccess, error)
 }
 
-var _ DBController = &MySQLController{}
+// DBControllerContext is the context-aware counterpart of DBController.
+type DBControllerContext interface {
+	// CreateDatabaseContext creates a database.
+	CreateDatabaseContext(ctx context.Context, dbName string, opts ...DatabaseOption) error
+	// AlterDatabaseContext changes the defaults of a database.
+	AlterDatabaseContext(ctx context.Context, dbName string, opts ...DatabaseOption) error
+	// DatabaseInfoContext returns the defaults of a database.
+	DatabaseInfoContext(ctx context.Context, dbName string) (DatabaseInfo, error)
+	// DeleteDatabaseContext deletes a database.
+	DeleteDatabaseContext(ctx context.Context, dbName string) error
+	// RenameDatabaseContext renames a database.
+	RenameDatabaseContext(ctx context.Context, oldName, newName string) error
+	// ListDatabasesContext returns a list of databases.
+	ListDatabasesContext(ctx context.Context) ([]string, error)
+	// DatabaseExistsContext returns true if the database exists.
+	DatabaseExistsContext(ctx context.Context, dbName string) (bool, error)
+	// SizeContext returns the size of the database in Bytes.
+	SizeContext(ctx context.Context, dbName string) (int, error)
+	// SizeAllContext returns the size of every database in Bytes.
+	SizeAllContext(ctx context.Context) (map[string]int64, error)
+	// TablesContext returns a list of tables in the database.
+	TablesContext(ctx context.Context, dbName string) ([]string, error)
+	// TableStatsContext returns the statistics of every table in the database.
+	TableStatsContext(ctx context.Context, dbName string) ([]TableStats, error)
+	// DatabaseUsersContext returns every account with any privilege on the database.
+	DatabaseUsersContext(ctx context.Context, dbName string) ([]DatabaseAccess, error)
+}
+
+var (
+	_ DBController        = &MySQLController{}
+	_ DBControllerContext = &MySQLController{}
+)
 
 var baseDBs = []string{"information_schema", "mysql", "performance_schema", "sys"}
 
@@ -32,8 +76,23 @@ var (
 	ErrDBDoesNotExist = fmt.Errorf("database does not exist")
 )
 
+// DatabaseInfo are the defaults of a database, from
+// information_schema.schemata.
+type DatabaseInfo struct {
+	Name         string
+	CharacterSet string
+	Collation    string
+	// Encrypted is the default encryption of new tables. It is always false
+	// before MySQL 8.0.16.
+	Encrypted bool
+}
+
 type MySQLController struct {
-	db *sql.DB
+	db                     *sql.DB
+	passwordPolicy         PasswordValidator
+	passwordGenerator      PasswordGenerator
+	privilegedGlobalGrants bool
+	quotas                 quotas
 }
 
 // Option is a function that configures the MySQLController.
@@ -67,40 +126,111 @@ func (c *MySQLController) Close() error {
 	return c.db.Close()
 }
 
-func (c *MySQLController) CreateDatabase(dbName string) error {
+func (c *MySQLController) CreateDatabase(dbName string, opts ...DatabaseOption) error {
+	return c.CreateDatabaseContext(context.Background(), dbName, opts...)
+}
+
+// CreateDatabaseContext creates a database.
+func (c *MySQLController) CreateDatabaseContext(ctx context.Context, dbName string, opts ...DatabaseOption) error {
 	err := validateDBName(dbName)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.db.Exec(fmt.Sprintf("CREATE DATABASE `%s`", dbName))
+	o := newDatabaseOptions(opts)
+	err = o.validate()
 	if err != nil {
-		if strings.Contains(err.Error(), "Error 1007") {
-			return ErrDBExists
-		}
+		return err
 	}
-	return err
+
+	_, err = c.db.ExecContext(ctx, "CREATE DATABASE "+quoteIdentifier(dbName)+o.clauses())
+	return classifyError(err, map[uint16]error{erDBCreateExists: ErrDBExists})
 }
 
-func (c *MySQLController) DeleteDatabase(dbName string) error {
+// AlterDatabase changes the defaults of a database.
+func (c *MySQLController) AlterDatabase(dbName string, opts ...DatabaseOption) error {
+	return c.AlterDatabaseContext(context.Background(), dbName, opts...)
+}
+
+// AlterDatabaseContext changes the defaults of a database. Existing tables
+// keep their character set, collation and encryption.
+func (c *MySQLController) AlterDatabaseContext(ctx context.Context, dbName string, opts ...DatabaseOption) error {
 	err := validateDBName(dbName)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.db.Exec(fmt.Sprintf("DROP DATABASE `%s`", dbName))
+	o := newDatabaseOptions(opts)
+	if o.empty() {
+		return fmt.Errorf("no database option given")
+	}
+	err = o.validate()
 	if err != nil {
-		if strings.Contains(err.Error(), "Error 1008") {
-			return ErrDBDoesNotExist
-		}
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, "ALTER DATABASE "+quoteIdentifier(dbName)+o.clauses())
+	return classifyError(err, nil)
+}
+
+// DatabaseInfo returns the defaults of a database.
+func (c *MySQLController) DatabaseInfo(dbName string) (DatabaseInfo, error) {
+	return c.DatabaseInfoContext(context.Background(), dbName)
+}
+
+// DatabaseInfoContext returns the defaults of a database.
+func (c *MySQLController) DatabaseInfoContext(ctx context.Context, dbName string) (DatabaseInfo, error) {
+	err := validateDBName(dbName)
+	if err != nil {
+		return DatabaseInfo{}, err
+	}
+
+	var info DatabaseInfo
+	err = c.db.QueryRowContext(ctx, "SELECT schema_name, default_character_set_name, default_collation_name FROM information_schema.schemata WHERE schema_name = ?", dbName).
+		Scan(&info.Name, &info.CharacterSet, &info.Collation)
+	if err == sql.ErrNoRows {
+		return DatabaseInfo{}, ErrDBDoesNotExist
+	}
+	if err != nil {
+		return DatabaseInfo{}, classifyError(err, nil)
+	}
+
+	// default_encryption only exists since MySQL 8.0.16; older servers report
+	// an unknown column and the database is treated as unencrypted
+	var encryption string
+	err = c.db.QueryRowContext(ctx, "SELECT default_encryption FROM information_schema.schemata WHERE schema_name = ?", dbName).Scan(&encryption)
+	if err = classifyError(err, map[uint16]error{erBadFieldError: nil}); err != nil {
+		return DatabaseInfo{}, err
+	}
+	info.Encrypted = encryption == "YES"
+
+	return info, nil
+}
+
+func (c *MySQLController) DeleteDatabase(dbName string) error {
+	return c.DeleteDatabaseContext(context.Background(), dbName)
+}
+
+// DeleteDatabaseContext deletes a database.
+func (c *MySQLController) DeleteDatabaseContext(ctx context.Context, dbName string) error {
+	err := validateDBName(dbName)
+	if err != nil {
+		return err
 	}
-	return err
+
+	_, err = c.db.ExecContext(ctx, "DROP DATABASE "+quoteIdentifier(dbName))
+	return classifyError(err, map[uint16]error{erDBDropExists: ErrDBDoesNotExist})
 }
 
 func (c *MySQLController) ListDatabases() ([]string, error) {
-	rows, err := c.db.Query("SHOW DATABASES")
+	return c.ListDatabasesContext(context.Background())
+}
+
+// ListDatabasesContext returns a list of databases.
+func (c *MySQLController) ListDatabasesContext(ctx context.Context) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, "SHOW DATABASES")
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err, nil)
 	}
 	defer rows.Close()
 	var databases []string
@@ -117,31 +247,62 @@ func (c *MySQLController) ListDatabases() ([]string, error) {
 }
 
 func (c *MySQLController) DatabaseExists(dbName string) (bool, error) {
+	return c.DatabaseExistsContext(context.Background(), dbName)
+}
+
+// DatabaseExistsContext returns true if the database exists.
+func (c *MySQLController) DatabaseExistsContext(ctx context.Context, dbName string) (bool, error) {
 	err := validateDBName(dbName)
 	if err != nil {
 		return false, err
 	}
 
 	var count int
-	err = c.db.QueryRow("SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = ?", dbName).Scan(&count)
+	err = c.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = ?", dbName).Scan(&count)
 	if err != nil {
-		return false, err
+		return false, classifyError(err, nil)
 	}
 	return count > 0, nil
 }
 
 // Size returns the size of the database in Bytes.
 func (c *MySQLController) Size(dbName string) (int, error) {
-	err := validateDBName(dbName)
+	return c.SizeContext(context.Background(), dbName)
+}
+
+// SizeContext returns the size of the database in Bytes. On 32-bit builds,
+// sizes that do not fit an int are returned as an error; SizeAll reports them
+// as int64.
+func (c *MySQLController) SizeContext(ctx context.Context, dbName string) (int, error) {
+	size, err := c.databaseSize(ctx, dbName)
 	if err != nil {
 		return 0, err
 	}
+	return sizeToInt(size)
+}
 
-	var size *int
-	err = c.db.QueryRow("SELECT SUM(data_length + index_length) FROM information_schema.tables WHERE table_schema = ?", dbName).Scan(&size)
+// sizeToInt converts a size in Bytes to an int, failing instead of wrapping
+// around when it does not fit.
+func sizeToInt(size int64) (int, error) {
+	if size > math.MaxInt {
+		return 0, fmt.Errorf("database size of %d Bytes overflows int", size)
+	}
+	return int(size), nil
+}
+
+// databaseSize returns the size of the database in Bytes, as an int64 that
+// cannot overflow on 32-bit builds.
+func (c *MySQLController) databaseSize(ctx context.Context, dbName string) (int64, error) {
+	err := validateDBName(dbName)
 	if err != nil {
 		return 0, err
 	}
+
+	var size *int64
+	err = c.db.QueryRowContext(ctx, "SELECT SUM(data_length + index_length) FROM information_schema.tables WHERE table_schema = ?", dbName).Scan(&size)
+	if err != nil {
+		return 0, classifyError(err, nil)
+	}
 	if size == nil {
 		return 0, nil
 	}
@@ -149,17 +310,54 @@ func (c *MySQLController) Size(dbName string) (int, error) {
 	return *size, nil
 }
 
+// SizeAll returns the size of every database in Bytes.
+func (c *MySQLController) SizeAll() (map[string]int64, error) {
+	return c.SizeAllContext(context.Background())
+}
+
+// SizeAllContext returns the size of every database in Bytes, in a single
+// query. Databases without tables have a size of 0.
+func (c *MySQLController) SizeAllContext(ctx context.Context) (map[string]int64, error) {
+	q := "SELECT s.schema_name, COALESCE(SUM(t.data_length + t.index_length), 0) FROM information_schema.schemata s" +
+		" LEFT JOIN information_schema.tables t ON t.table_schema = s.schema_name GROUP BY s.schema_name"
+	rows, err := c.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, classifyError(err, nil)
+	}
+	defer rows.Close()
+
+	sizes := map[string]int64{}
+	for rows.Next() {
+		var (
+			dbName string
+			size   int64
+		)
+		err = rows.Scan(&dbName, &size)
+		if err != nil {
+			return nil, err
+		}
+		if !contains(baseDBs, dbName) {
+			sizes[dbName] = size
+		}
+	}
+	return sizes, rows.Err()
+}
+
 // Tables returns a list of tables in the database.
 func (c *MySQLController) Tables(dbName string) ([]string, error) {
+	return c.TablesContext(context.Background(), dbName)
+}
+
+// TablesContext returns a list of tables in the database.
+func (c *MySQLController) TablesContext(ctx context.Context, dbName string) ([]string, error) {
 	err := validateDBName(dbName)
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err, nil)
 	}
 
-	q := fmt.Sprintf("SELECT table_name FROM information_schema.tables WHERE table_schema = '%s'", dbName)
-	rows, err := c.db.Query(q)
+	rows, err := c.db.QueryContext(ctx, "SELECT table_name FROM information_schema.tables WHERE table_schema = ?", dbName)
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err, nil)
 	}
 	defer rows.Close()
 	var tables []string
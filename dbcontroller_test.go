@@ -1,8 +1,10 @@
 package mysqlctl
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"math/rand"
 	"testing"
 
@@ -51,6 +53,34 @@ func TestMySQLController_CreateDatabase(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMySQLController_DatabaseOptions(t *testing.T) {
+	c := createTestController()
+	err := c.CreateDatabase(testDB, WithCharset("latin1"), WithCollation("latin1_swedish_ci"))
+	assert.NoError(t, err)
+	defer c.DeleteDatabase(testDB)
+
+	info, err := c.DatabaseInfo(testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, DatabaseInfo{Name: testDB, CharacterSet: "latin1", Collation: "latin1_swedish_ci"}, info)
+
+	err = c.AlterDatabase(testDB, WithCharset("utf8mb4"), WithCollation("utf8mb4_bin"))
+	assert.NoError(t, err)
+
+	info, err = c.DatabaseInfo(testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, "utf8mb4", info.CharacterSet)
+	assert.Equal(t, "utf8mb4_bin", info.Collation)
+
+	err = c.AlterDatabase(testDB)
+	assert.Error(t, err)
+
+	err = c.CreateDatabase("test-db-2", WithCharset("not a charset"))
+	assert.Error(t, err)
+
+	_, err = c.DatabaseInfo("test-db-2")
+	assert.Equal(t, ErrDBDoesNotExist, err)
+}
+
 func TestMySQLController_DeleteDatabase(t *testing.T) {
 	c := createTestController()
 	err := c.DeleteDatabase(testDB)
@@ -145,6 +175,50 @@ func TestMySQLController_Size(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMySQLController_SizeAll(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateDatabase("test-db-2")
+	defer c.DeleteDatabase("test-db-2")
+
+	_, err := c.db.Exec("CREATE TABLE `test-db`.test (id INT, name VARCHAR(255))")
+	assert.NoError(t, err)
+
+	sizes, err := c.SizeAll()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16384), sizes[testDB])
+	assert.Contains(t, sizes, "test-db-2")
+	assert.Equal(t, int64(0), sizes["test-db-2"])
+
+	for _, name := range baseDBs {
+		assert.NotContains(t, sizes, name)
+	}
+
+	size, err := c.Size(testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(size), sizes[testDB])
+
+	size64, err := c.databaseSize(context.Background(), testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, sizes[testDB], size64)
+}
+
+func Test_sizeToInt(t *testing.T) {
+	size, err := sizeToInt(1 << 30)
+	assert.NoError(t, err)
+	assert.Equal(t, 1<<30, size)
+
+	size, err = sizeToInt(math.MaxInt)
+	assert.NoError(t, err)
+	assert.Equal(t, math.MaxInt, size)
+
+	if math.MaxInt < math.MaxInt64 {
+		_, err = sizeToInt(math.MaxInt64)
+		assert.Error(t, err)
+	}
+}
+
 func openMySQLWithDB(username, password, database string) (*sql.DB, error) {
 	connStr := fmt.Sprintf("%s:%s@tcp(127.0.0.1:6603)/%s", username, password, database)
 
@@ -249,3 +323,40 @@ func TestMySQLController_Tables(t *testing.T) {
 	err = c.DeleteDatabase(testDB)
 	assert.NoError(t, err)
 }
+
+func TestMySQLController_CanceledContext(t *testing.T) {
+	c := createTestController()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.CreateDatabaseContext(ctx, testDB)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = c.ListDatabasesContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = c.CreateUserContext(ctx, testUser, testPassword)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = c.GrantContext(ctx, "select", testDB, testUser)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMySQLController_QuotedDatabaseNames(t *testing.T) {
+	c := createTestController()
+	for _, name := range []string{"test`db", "test'db", `test\db`, "test`; DROP DATABASE mysql; --"} {
+		err := c.CreateDatabase(name)
+		assert.NoError(t, err)
+
+		exists, err := c.DatabaseExists(name)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		tables, err := c.Tables(name)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(tables))
+
+		err = c.DeleteDatabase(name)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,114 @@
+package mysqlctl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+var (
+	ErrAccessDenied       = fmt.Errorf("access denied")
+	ErrTableDoesNotExist  = fmt.Errorf("table does not exist")
+	ErrGrantDoesNotExist  = fmt.Errorf("grant does not exist")
+	ErrLockWaitTimeout    = fmt.Errorf("lock wait timeout exceeded")
+	ErrDeadlock           = fmt.Errorf("deadlock found")
+	ErrReadOnly           = fmt.Errorf("server is read-only")
+	ErrTooManyConnections = fmt.Errorf("too many connections")
+	ErrQueryInterrupted   = fmt.Errorf("query interrupted")
+)
+
+// MySQL server error numbers, named after their ER_* symbols.
+const (
+	erDBCreateExists             = 1007
+	erDBDropExists               = 1008
+	erConCount                   = 1040
+	erDBAccessDenied             = 1044
+	erAccessDenied               = 1045
+	erBadDB                      = 1049
+	erBadFieldError              = 1054
+	erPasswordNoMatch            = 1133
+	erNonexistingGrant           = 1141
+	erTableAccessDenied          = 1142
+	erColumnAccessDenied         = 1143
+	erNoSuchTable                = 1146
+	erNonexistingTableGrant      = 1147
+	erTooManyUserConnections     = 1203
+	erLockWaitTimeout            = 1205
+	erLockDeadlock               = 1213
+	erSpecificAccessDenied       = 1227
+	erOptionPreventsStatement    = 1290
+	erQueryInterrupted           = 1317
+	erCannotUser                 = 1396
+	erCantExecuteInReadOnlyTrans = 1792
+	erReadOnlyMode               = 1836
+	erQueryTimeout               = 3024
+	erUnknownAuthID              = 3523
+)
+
+// errorsByNumber maps server error numbers that mean the same thing for every
+// statement to their sentinel errors.
+var errorsByNumber = map[uint16]error{
+	erDBCreateExists:             ErrDBExists,
+	erDBDropExists:               ErrDBDoesNotExist,
+	erBadDB:                      ErrDBDoesNotExist,
+	erPasswordNoMatch:            ErrUserDoesNotExist,
+	erNoSuchTable:                ErrTableDoesNotExist,
+	erNonexistingGrant:           ErrGrantDoesNotExist,
+	erNonexistingTableGrant:      ErrGrantDoesNotExist,
+	erDBAccessDenied:             ErrAccessDenied,
+	erAccessDenied:               ErrAccessDenied,
+	erTableAccessDenied:          ErrAccessDenied,
+	erColumnAccessDenied:         ErrAccessDenied,
+	erSpecificAccessDenied:       ErrAccessDenied,
+	erLockWaitTimeout:            ErrLockWaitTimeout,
+	erLockDeadlock:               ErrDeadlock,
+	erOptionPreventsStatement:    ErrReadOnly,
+	erCantExecuteInReadOnlyTrans: ErrReadOnly,
+	erReadOnlyMode:               ErrReadOnly,
+	erConCount:                   ErrTooManyConnections,
+	erTooManyUserConnections:     ErrTooManyConnections,
+	erQueryInterrupted:           ErrQueryInterrupted,
+	erQueryTimeout:               ErrQueryInterrupted,
+}
+
+// Error is a MySQL server error classified as one of the package's sentinel
+// errors. errors.Is matches it against Kind and errors.As can still reach the
+// underlying *mysql.MySQLError.
+type Error struct {
+	Kind  error
+	Cause *mysql.MySQLError
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Kind, e.Cause)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// classifyError translates a MySQL server error.
+//
+// expected maps error numbers to the outcome they mean for the statement being
+// run (e.g. 1396 is ErrUserExists for CREATE USER but ErrUserDoesNotExist for
+// DROP USER); those sentinels are returned as they are. Any other known error
+// number is returned as an *Error, and everything else is returned unchanged.
+func classifyError(err error, expected map[uint16]error) error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+
+	if sentinel, ok := expected[mysqlErr.Number]; ok {
+		return sentinel
+	}
+	if kind, ok := errorsByNumber[mysqlErr.Number]; ok {
+		return &Error{Kind: kind, Cause: mysqlErr}
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package mysqlctl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_classifyError(t *testing.T) {
+	assert.NoError(t, classifyError(nil, nil))
+
+	plain := fmt.Errorf("connection refused")
+	assert.Equal(t, plain, classifyError(plain, nil))
+
+	unknown := &mysql.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"}
+	assert.Equal(t, unknown, classifyError(unknown, nil))
+
+	cannotUser := &mysql.MySQLError{Number: erCannotUser, Message: "Operation CREATE USER failed"}
+	assert.Equal(t, ErrUserExists, classifyError(cannotUser, map[uint16]error{erCannotUser: ErrUserExists}))
+	assert.Equal(t, ErrUserDoesNotExist, classifyError(cannotUser, map[uint16]error{erCannotUser: ErrUserDoesNotExist}))
+	assert.Equal(t, cannotUser, classifyError(cannotUser, nil))
+
+	// operation-specific outcomes stay comparable with ==
+	exists := &mysql.MySQLError{Number: erDBCreateExists, Message: "Can't create database 'test-db'; database exists"}
+	assert.True(t, ErrDBExists == classifyError(exists, map[uint16]error{erDBCreateExists: ErrDBExists}))
+	assert.False(t, ErrDBExists == classifyError(exists, nil))
+
+	denied := &mysql.MySQLError{Number: erDBAccessDenied, Message: "Access denied for user 'test-user'@'%' to database 'test-db'"}
+	err := classifyError(fmt.Errorf("error granting privileges: %w", denied), nil)
+	assert.ErrorIs(t, err, ErrAccessDenied)
+	assert.NotErrorIs(t, err, ErrReadOnly)
+	assert.Contains(t, err.Error(), "test-db")
+
+	var mysqlErr *mysql.MySQLError
+	assert.True(t, errors.As(err, &mysqlErr))
+	assert.Equal(t, uint16(erDBAccessDenied), mysqlErr.Number)
+
+	var classified *Error
+	assert.True(t, errors.As(err, &classified))
+	assert.Equal(t, ErrAccessDenied, classified.Kind)
+
+	for number, kind := range errorsByNumber {
+		err := classifyError(&mysql.MySQLError{Number: number}, nil)
+		assert.ErrorIs(t, err, kind)
+	}
+}
@@ -0,0 +1,91 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_GlobalGrants(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	for _, g := range []string{"process", "REPLICATION CLIENT", "reload", "show databases"} {
+		err := c.GrantGlobal(g, testUser)
+		assert.NoError(t, err)
+
+		b, err := c.GlobalGrantExists(g, testUser)
+		assert.NoError(t, err)
+		assert.True(t, b)
+
+		err = c.RevokeGlobal(g, testUser)
+		assert.NoError(t, err)
+
+		b, err = c.GlobalGrantExists(g, testUser)
+		assert.NoError(t, err)
+		assert.False(t, b)
+	}
+
+	err := c.GrantGlobal("select", testUser)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+
+	err = c.GrantGlobal("all privileges", testUser)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+
+	_, err = c.GlobalGrantExists("process", "")
+	assert.Error(t, err)
+}
+
+func TestMySQLController_PrivilegedGlobalGrants(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.GrantGlobal("replication slave", testUser)
+	assert.ErrorIs(t, err, ErrPrivilegedGrant)
+
+	for _, g := range privilegedGlobalGrants {
+		err := c.GrantGlobal(g, testUser)
+		assert.ErrorIs(t, err, ErrPrivilegedGrant)
+
+		b, err := c.GlobalGrantExists(g, testUser)
+		assert.NoError(t, err)
+		assert.False(t, b)
+	}
+
+	WithPrivilegedGlobalGrants()(c)
+
+	for _, g := range privilegedGlobalGrants {
+		err := c.GrantGlobal(g, testUser)
+		assert.NoError(t, err)
+
+		b, err := c.GlobalGrantExists(g, testUser)
+		assert.NoError(t, err)
+		assert.True(t, b)
+
+		err = c.RevokeGlobal(g, testUser)
+		assert.NoError(t, err)
+	}
+}
+
+func Test_validateGlobalGrant(t *testing.T) {
+	g, err := validateGlobalGrant("replication client", NewAccount(testUser))
+	assert.NoError(t, err)
+	assert.Equal(t, "REPLICATION CLIENT", g)
+
+	for g := range grants {
+		if _, ok := globalGrants[g]; ok {
+			t.Errorf("%s is both a database and a global grant", g)
+		}
+	}
+
+	for _, g := range privilegedGlobalGrants {
+		_, err := validateGlobalGrant(g, NewAccount(testUser))
+		assert.NoError(t, err)
+	}
+
+	for _, g := range []string{"CREATE USER", "FILE", "REPLICATION SLAVE", "SHUTDOWN"} {
+		assert.Contains(t, privilegedGlobalGrants, g)
+	}
+}
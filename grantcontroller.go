@@ -1,28 +1,153 @@
 package mysqlctl
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 )
 
 type GrantController interface {
-	Grant(grantName, dbName, username string) error
+	Grant(grantName, dbName, username string, opts ...GrantOption) error
 	GrantExists(grantName, dbName, username string) (bool, error)
-	GrantAll(dbName, username string) error
+	GrantAll(dbName, username string, opts ...GrantOption) error
 	RevokeAll(dbName, username string) error
 	Revoke(grantName, dbName, username string) error
+
+	GrantToAccount(grantName, dbName string, account Account, opts ...GrantOption) error
+	GrantExistsForAccount(grantName, dbName string, account Account) (bool, error)
+	GrantAllToAccount(dbName string, account Account, opts ...GrantOption) error
+	RevokeAllFromAccount(dbName string, account Account) error
+	RevokeFromAccount(grantName, dbName string, account Account) error
+
+	GrantMany(dbName, username string, grantNames ...string) error
+	RevokeMany(dbName, username string, grantNames ...string) error
+	GrantManyToAccount(dbName string, account Account, grantNames ...string) error
+	RevokeManyFromAccount(dbName string, account Account, grantNames ...string) error
+
+	GrantOptionExists(dbName, username string) (bool, error)
+	RevokeGrantOption(dbName, username string) error
+	GrantOptionExistsForAccount(dbName string, account Account) (bool, error)
+	RevokeGrantOptionFromAccount(dbName string, account Account) error
+
+	GrantPattern(grantName, pattern, username string, opts ...GrantOption) error
+	RevokePattern(grantName, pattern, username string) error
+	PatternGrantExists(grantName, pattern, username string) (bool, error)
+	GrantPatternToAccount(grantName, pattern string, account Account, opts ...GrantOption) error
+	RevokePatternFromAccount(grantName, pattern string, account Account) error
+	PatternGrantExistsForAccount(grantName, pattern string, account Account) (bool, error)
+
+	ListGrants(username string) (Privileges, error)
+	ListGrantsForAccount(account Account) (Privileges, error)
+
+	ApplyProfile(dbName, username string, profile Profile) error
+	DetectProfile(dbName, username string) (Profile, error)
+	ApplyProfileToAccount(dbName string, account Account, profile Profile) error
+	DetectProfileForAccount(dbName string, account Account) (Profile, error)
+
+	SetGrants(dbName, username string, grantNames []string) (GrantChanges, error)
+	SetGrantsForAccount(dbName string, account Account, grantNames []string) (GrantChanges, error)
+
+	GrantTable(grantName, dbName, tableName, username string) error
+	RevokeTable(grantName, dbName, tableName, username string) error
+	TableGrantExists(grantName, dbName, tableName, username string) (bool, error)
+	GrantColumns(grantName, dbName, tableName string, columns []string, username string) error
+	RevokeColumns(grantName, dbName, tableName string, columns []string, username string) error
+	ColumnGrantExists(grantName, dbName, tableName string, columns []string, username string) (bool, error)
+
+	GrantTableToAccount(grantName, dbName, tableName string, account Account) error
+	RevokeTableFromAccount(grantName, dbName, tableName string, account Account) error
+	TableGrantExistsForAccount(grantName, dbName, tableName string, account Account) (bool, error)
+	GrantColumnsToAccount(grantName, dbName, tableName string, columns []string, account Account) error
+	RevokeColumnsFromAccount(grantName, dbName, tableName string, columns []string, account Account) error
+	ColumnGrantExistsForAccount(grantName, dbName, tableName string, columns []string, account Account) (bool, error)
+}
+
+// GrantControllerContext is the context-aware counterpart of GrantController.
+type GrantControllerContext interface {
+	GrantContext(ctx context.Context, grantName, dbName, username string, opts ...GrantOption) error
+	GrantExistsContext(ctx context.Context, grantName, dbName, username string) (bool, error)
+	GrantAllContext(ctx context.Context, dbName, username string, opts ...GrantOption) error
+	RevokeAllContext(ctx context.Context, dbName, username string) error
+	RevokeContext(ctx context.Context, grantName, dbName, username string) error
+
+	GrantToAccountContext(ctx context.Context, grantName, dbName string, account Account, opts ...GrantOption) error
+	GrantExistsForAccountContext(ctx context.Context, grantName, dbName string, account Account) (bool, error)
+	GrantAllToAccountContext(ctx context.Context, dbName string, account Account, opts ...GrantOption) error
+	RevokeAllFromAccountContext(ctx context.Context, dbName string, account Account) error
+	RevokeFromAccountContext(ctx context.Context, grantName, dbName string, account Account) error
+
+	GrantManyContext(ctx context.Context, dbName, username string, grantNames ...string) error
+	RevokeManyContext(ctx context.Context, dbName, username string, grantNames ...string) error
+	GrantManyToAccountContext(ctx context.Context, dbName string, account Account, grantNames ...string) error
+	RevokeManyFromAccountContext(ctx context.Context, dbName string, account Account, grantNames ...string) error
+
+	GrantOptionExistsContext(ctx context.Context, dbName, username string) (bool, error)
+	RevokeGrantOptionContext(ctx context.Context, dbName, username string) error
+	GrantOptionExistsForAccountContext(ctx context.Context, dbName string, account Account) (bool, error)
+	RevokeGrantOptionFromAccountContext(ctx context.Context, dbName string, account Account) error
+
+	GrantPatternContext(ctx context.Context, grantName, pattern, username string, opts ...GrantOption) error
+	RevokePatternContext(ctx context.Context, grantName, pattern, username string) error
+	PatternGrantExistsContext(ctx context.Context, grantName, pattern, username string) (bool, error)
+	GrantPatternToAccountContext(ctx context.Context, grantName, pattern string, account Account, opts ...GrantOption) error
+	RevokePatternFromAccountContext(ctx context.Context, grantName, pattern string, account Account) error
+	PatternGrantExistsForAccountContext(ctx context.Context, grantName, pattern string, account Account) (bool, error)
+
+	ListGrantsContext(ctx context.Context, username string) (Privileges, error)
+	ListGrantsForAccountContext(ctx context.Context, account Account) (Privileges, error)
+
+	ApplyProfileContext(ctx context.Context, dbName, username string, profile Profile) error
+	DetectProfileContext(ctx context.Context, dbName, username string) (Profile, error)
+	ApplyProfileToAccountContext(ctx context.Context, dbName string, account Account, profile Profile) error
+	DetectProfileForAccountContext(ctx context.Context, dbName string, account Account) (Profile, error)
+
+	SetGrantsContext(ctx context.Context, dbName, username string, grantNames []string) (GrantChanges, error)
+	SetGrantsForAccountContext(ctx context.Context, dbName string, account Account, grantNames []string) (GrantChanges, error)
+
+	GrantTableContext(ctx context.Context, grantName, dbName, tableName, username string) error
+	RevokeTableContext(ctx context.Context, grantName, dbName, tableName, username string) error
+	TableGrantExistsContext(ctx context.Context, grantName, dbName, tableName, username string) (bool, error)
+	GrantColumnsContext(ctx context.Context, grantName, dbName, tableName string, columns []string, username string) error
+	RevokeColumnsContext(ctx context.Context, grantName, dbName, tableName string, columns []string, username string) error
+	ColumnGrantExistsContext(ctx context.Context, grantName, dbName, tableName string, columns []string, username string) (bool, error)
+
+	GrantTableToAccountContext(ctx context.Context, grantName, dbName, tableName string, account Account) error
+	RevokeTableFromAccountContext(ctx context.Context, grantName, dbName, tableName string, account Account) error
+	TableGrantExistsForAccountContext(ctx context.Context, grantName, dbName, tableName string, account Account) (bool, error)
+	GrantColumnsToAccountContext(ctx context.Context, grantName, dbName, tableName string, columns []string, account Account) error
+	RevokeColumnsFromAccountContext(ctx context.Context, grantName, dbName, tableName string, columns []string, account Account) error
+	ColumnGrantExistsForAccountContext(ctx context.Context, grantName, dbName, tableName string, columns []string, account Account) (bool, error)
 }
 
 // just checking if the database name is valid
-var _ GrantController = &MySQLController{}
+var (
+	_ GrantController        = &MySQLController{}
+	_ GrantControllerContext = &MySQLController{}
+)
 
 var (
 	ErrInvalidGrant = fmt.Errorf("invalid grant")
 )
 
 // GrantAll grants all privileges for the given database and user
-func (c *MySQLController) GrantAll(dbName, username string) error {
-	ok, err := c.UserExists(username)
+func (c *MySQLController) GrantAll(dbName, username string, opts ...GrantOption) error {
+	return c.GrantAllContext(context.Background(), dbName, username, opts...)
+}
+
+// GrantAllContext grants all privileges for the given database and user
+func (c *MySQLController) GrantAllContext(ctx context.Context, dbName, username string, opts ...GrantOption) error {
+	return c.GrantAllToAccountContext(ctx, dbName, NewAccount(username), opts...)
+}
+
+// GrantAllToAccount grants all privileges for the given database and account
+func (c *MySQLController) GrantAllToAccount(dbName string, account Account, opts ...GrantOption) error {
+	return c.GrantAllToAccountContext(context.Background(), dbName, account, opts...)
+}
+
+// GrantAllToAccountContext grants all privileges for the given database and account
+func (c *MySQLController) GrantAllToAccountContext(ctx context.Context, dbName string, account Account, opts ...GrantOption) error {
+	ok, err := c.AccountExistsContext(ctx, account)
 	if err != nil {
 		return fmt.Errorf("error checking if user exists: %w", err)
 	}
@@ -30,7 +155,7 @@ func (c *MySQLController) GrantAll(dbName, username string) error {
 		return ErrUserDoesNotExist
 	}
 
-	ok, err = c.DatabaseExists(dbName)
+	ok, err = c.DatabaseExistsContext(ctx, dbName)
 	if err != nil {
 		return fmt.Errorf("error checking if database exists: %w", err)
 	}
@@ -38,42 +163,68 @@ func (c *MySQLController) GrantAll(dbName, username string) error {
 		return ErrDBDoesNotExist
 	}
 
-	_, err = c.db.Exec("GRANT ALL PRIVILEGES ON `" + dbName + "`.* TO '" + username + "'@'%'")
+	_, err = c.db.ExecContext(ctx, "GRANT ALL PRIVILEGES ON "+quoteDatabase(dbName)+".* TO "+quoteAccount(account)+newGrantOptions(opts).clause())
 	if err != nil {
-		return fmt.Errorf("error granting privileges: %w", err)
+		return fmt.Errorf("error granting privileges: %w", classifyError(err, nil))
 	}
 	return nil
 }
 
 // RevokeAll revokes all privileges for the given database and user
 func (c *MySQLController) RevokeAll(dbName, username string) error {
+	return c.RevokeAllContext(context.Background(), dbName, username)
+}
+
+// RevokeAllContext revokes all privileges for the given database and user
+func (c *MySQLController) RevokeAllContext(ctx context.Context, dbName, username string) error {
+	return c.RevokeAllFromAccountContext(ctx, dbName, NewAccount(username))
+}
+
+// RevokeAllFromAccount revokes all privileges for the given database and account
+func (c *MySQLController) RevokeAllFromAccount(dbName string, account Account) error {
+	return c.RevokeAllFromAccountContext(context.Background(), dbName, account)
+}
+
+// RevokeAllFromAccountContext revokes all privileges for the given database and account
+func (c *MySQLController) RevokeAllFromAccountContext(ctx context.Context, dbName string, account Account) error {
 	err := validateDBName(dbName)
 	if err != nil {
 		return fmt.Errorf("error validating database name: %w", err)
 	}
 
-	err = validateUsername(username)
+	err = validateAccount(account)
 	if err != nil {
-		return fmt.Errorf("error validating username: %w", err)
+		return fmt.Errorf("error validating account: %w", err)
 	}
 
-	_, err = c.db.Exec("REVOKE ALL PRIVILEGES ON `" + dbName + "`.* FROM '" + username + "'@'%'")
-	if err != nil {
-		return fmt.Errorf("error revoking privileges: %w", err)
-	}
-	return nil
+	return c.revokeOnDatabase(ctx, "ALL PRIVILEGES", dbName, account)
 }
 
 // Grant grants the given grant to the given database and user
-func (c *MySQLController) Grant(grantName, dbName, username string) error {
+func (c *MySQLController) Grant(grantName, dbName, username string, opts ...GrantOption) error {
+	return c.GrantContext(context.Background(), grantName, dbName, username, opts...)
+}
+
+// GrantContext grants the given grant to the given database and user
+func (c *MySQLController) GrantContext(ctx context.Context, grantName, dbName, username string, opts ...GrantOption) error {
+	return c.GrantToAccountContext(ctx, grantName, dbName, NewAccount(username), opts...)
+}
+
+// GrantToAccount grants the given grant to the given database and account
+func (c *MySQLController) GrantToAccount(grantName, dbName string, account Account, opts ...GrantOption) error {
+	return c.GrantToAccountContext(context.Background(), grantName, dbName, account, opts...)
+}
+
+// GrantToAccountContext grants the given grant to the given database and account
+func (c *MySQLController) GrantToAccountContext(ctx context.Context, grantName, dbName string, account Account, opts ...GrantOption) error {
 	err := validateDBName(dbName)
 	if err != nil {
 		return fmt.Errorf("error validating database name: %w", err)
 	}
 
-	err = validateUsername(username)
+	err = validateAccount(account)
 	if err != nil {
-		return fmt.Errorf("error validating username: %w", err)
+		return fmt.Errorf("error validating account: %w", err)
 	}
 
 	grantName = strings.ToUpper(grantName)
@@ -82,24 +233,39 @@ func (c *MySQLController) Grant(grantName, dbName, username string) error {
 		return fmt.Errorf("error validating grant: %w", err)
 	}
 
-	q := fmt.Sprintf("GRANT %s ON `%s`.* TO '%s'@'%%'", grantName, dbName, username)
-	_, err = c.db.Exec(q)
+	q := fmt.Sprintf("GRANT %s ON %s.* TO %s%s", grantName, quoteDatabase(dbName), quoteAccount(account), newGrantOptions(opts).clause())
+	_, err = c.db.ExecContext(ctx, q)
 	if err != nil {
-		return fmt.Errorf("error granting privileges: %w", err)
+		return fmt.Errorf("error granting privileges: %w", classifyError(err, nil))
 	}
 	return nil
 }
 
 // Revoke revokes the given grant from the given database and user
 func (c *MySQLController) Revoke(grantName, dbName, username string) error {
+	return c.RevokeContext(context.Background(), grantName, dbName, username)
+}
+
+// RevokeContext revokes the given grant from the given database and user
+func (c *MySQLController) RevokeContext(ctx context.Context, grantName, dbName, username string) error {
+	return c.RevokeFromAccountContext(ctx, grantName, dbName, NewAccount(username))
+}
+
+// RevokeFromAccount revokes the given grant from the given database and account
+func (c *MySQLController) RevokeFromAccount(grantName, dbName string, account Account) error {
+	return c.RevokeFromAccountContext(context.Background(), grantName, dbName, account)
+}
+
+// RevokeFromAccountContext revokes the given grant from the given database and account
+func (c *MySQLController) RevokeFromAccountContext(ctx context.Context, grantName, dbName string, account Account) error {
 	err := validateDBName(dbName)
 	if err != nil {
 		return fmt.Errorf("error validating database name: %w", err)
 	}
 
-	err = validateUsername(username)
+	err = validateAccount(account)
 	if err != nil {
-		return fmt.Errorf("error validating username: %w", err)
+		return fmt.Errorf("error validating account: %w", err)
 	}
 
 	grantName = strings.ToUpper(grantName)
@@ -108,25 +274,80 @@ func (c *MySQLController) Revoke(grantName, dbName, username string) error {
 		return fmt.Errorf("error validating grant: %w", err)
 	}
 
-	q := fmt.Sprintf("REVOKE %s ON `%s`.* FROM '%s'@'%%'", grantName, dbName, username)
-	_, err = c.db.Exec(q)
-	if err != nil {
-		return fmt.Errorf("error revoking privileges: %w", err)
-	}
+	return c.revokeOnDatabase(ctx, grantName, dbName, account)
+}
 
-	return nil
+// GrantMany grants every given grant on the given database to the given user in a single statement
+func (c *MySQLController) GrantMany(dbName, username string, grantNames ...string) error {
+	return c.GrantManyContext(context.Background(), dbName, username, grantNames...)
+}
+
+// GrantManyContext grants every given grant on the given database to the given user in a single statement
+func (c *MySQLController) GrantManyContext(ctx context.Context, dbName, username string, grantNames ...string) error {
+	return c.GrantManyToAccountContext(ctx, dbName, NewAccount(username), grantNames...)
+}
+
+// GrantManyToAccount grants every given grant on the given database to the given account in a single statement
+func (c *MySQLController) GrantManyToAccount(dbName string, account Account, grantNames ...string) error {
+	return c.GrantManyToAccountContext(context.Background(), dbName, account, grantNames...)
+}
+
+// GrantManyToAccountContext grants every given grant on the given database to
+// the given account in a single statement. Nothing is granted if any grant is
+// invalid.
+func (c *MySQLController) GrantManyToAccountContext(ctx context.Context, dbName string, account Account, grantNames ...string) error {
+	return c.grantPrivileges(ctx, grantNames, dbName, account)
+}
+
+// RevokeMany revokes every given grant on the given database from the given user in a single statement
+func (c *MySQLController) RevokeMany(dbName, username string, grantNames ...string) error {
+	return c.RevokeManyContext(context.Background(), dbName, username, grantNames...)
+}
+
+// RevokeManyContext revokes every given grant on the given database from the given user in a single statement
+func (c *MySQLController) RevokeManyContext(ctx context.Context, dbName, username string, grantNames ...string) error {
+	return c.RevokeManyFromAccountContext(ctx, dbName, NewAccount(username), grantNames...)
+}
+
+// RevokeManyFromAccount revokes every given grant on the given database from the given account in a single statement
+func (c *MySQLController) RevokeManyFromAccount(dbName string, account Account, grantNames ...string) error {
+	return c.RevokeManyFromAccountContext(context.Background(), dbName, account, grantNames...)
+}
+
+// RevokeManyFromAccountContext revokes every given grant on the given database
+// from the given account in a single statement. Nothing is revoked if any
+// grant is invalid.
+func (c *MySQLController) RevokeManyFromAccountContext(ctx context.Context, dbName string, account Account, grantNames ...string) error {
+	return c.revokePrivileges(ctx, grantNames, dbName, account)
 }
 
 // GrantExists returns true if the given grant exists for the given database and user
 func (c *MySQLController) GrantExists(grantName, dbName, username string) (bool, error) {
+	return c.GrantExistsContext(context.Background(), grantName, dbName, username)
+}
+
+// GrantExistsContext returns true if the given grant exists for the given database and user
+func (c *MySQLController) GrantExistsContext(ctx context.Context, grantName, dbName, username string) (bool, error) {
+	return c.GrantExistsForAccountContext(ctx, grantName, dbName, NewAccount(username))
+}
+
+// GrantExistsForAccount returns true if the given grant exists for the given database and account
+func (c *MySQLController) GrantExistsForAccount(grantName, dbName string, account Account) (bool, error) {
+	return c.GrantExistsForAccountContext(context.Background(), grantName, dbName, account)
+}
+
+// GrantExistsForAccountContext returns true if the given grant exists for the
+// given database and account, either on the database itself or through a
+// pattern grant matching it.
+func (c *MySQLController) GrantExistsForAccountContext(ctx context.Context, grantName, dbName string, account Account) (bool, error) {
 	err := validateDBName(dbName)
 	if err != nil {
 		return false, fmt.Errorf("error validating database name: %w", err)
 	}
 
-	err = validateUsername(username)
+	err = validateAccount(account)
 	if err != nil {
-		return false, fmt.Errorf("error validating username: %w", err)
+		return false, fmt.Errorf("error validating account: %w", err)
 	}
 
 	grantName = strings.ToUpper(grantName)
@@ -135,25 +356,103 @@ func (c *MySQLController) GrantExists(grantName, dbName, username string) (bool,
 		return false, fmt.Errorf("error validating grant: %w", err)
 	}
 
-	grantColumn := grants[grantName]
-
-	q := fmt.Sprintf("SELECT COUNT(*) FROM mysql.db WHERE Db = '%s' AND User = '%s' AND %s = 'Y'", dbName, username, grantColumn)
-	var count int
-	err = c.db.QueryRow(q).Scan(&count)
+	// the column name comes from the grants map, never from the caller
+	ok, err := c.databasePrivilegeCovered(ctx, grants[grantName], dbName, account)
 	if err != nil {
 		return false, fmt.Errorf("error checking if grant exists: %w", err)
 	}
+	return ok, nil
+}
+
+// grantPrivileges grants every grant on the database to the account in a
+// single statement, after validating all of them.
+func (c *MySQLController) grantPrivileges(ctx context.Context, grantNames []string, dbName string, account Account, opts ...GrantOption) error {
+	privileges, err := validateGrants(grantNames)
+	if err != nil {
+		return fmt.Errorf("error validating grant: %w", err)
+	}
+
+	err = validateDBName(dbName)
+	if err != nil {
+		return fmt.Errorf("error validating database name: %w", err)
+	}
+
+	err = validateAccount(account)
+	if err != nil {
+		return fmt.Errorf("error validating account: %w", err)
+	}
+
+	q := fmt.Sprintf("GRANT %s ON %s.* TO %s%s", privileges, quoteDatabase(dbName), quoteAccount(account), newGrantOptions(opts).clause())
+	_, err = c.db.ExecContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error granting privileges: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// revokePrivileges revokes every grant on the database from the account in a
+// single statement, after validating all of them.
+func (c *MySQLController) revokePrivileges(ctx context.Context, grantNames []string, dbName string, account Account) error {
+	privileges, err := validateGrants(grantNames)
+	if err != nil {
+		return fmt.Errorf("error validating grant: %w", err)
+	}
+
+	err = validateDBName(dbName)
+	if err != nil {
+		return fmt.Errorf("error validating database name: %w", err)
+	}
+
+	err = validateAccount(account)
+	if err != nil {
+		return fmt.Errorf("error validating account: %w", err)
+	}
+
+	return c.revokeOnDatabase(ctx, privileges, dbName, account)
+}
+
+// validateGrants validates every grant and returns them as a privilege list
+// for a GRANT or REVOKE statement.
+func validateGrants(grantNames []string) (string, error) {
+	if len(grantNames) == 0 {
+		return "", ErrInvalidGrant
+	}
 
-	return count > 0, nil
+	upper := make([]string, len(grantNames))
+	for i, grantName := range grantNames {
+		upper[i] = strings.ToUpper(grantName)
+		err := validateGrant(upper[i])
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(upper, ", "), nil
+}
+
+// uniqueGrants returns the sorted grants without duplicates.
+func uniqueGrants(grantNames []string) []string {
+	sort.Strings(grantNames)
+	var unique []string
+	for i, grantName := range grantNames {
+		if i == 0 || grantName != grantNames[i-1] {
+			unique = append(unique, grantName)
+		}
+	}
+	return unique
 }
 
 // validateGrant checks if the given grant is valid
 func validateGrant(grantName string) error {
+	return validateGrantIn(grantName, grants)
+}
+
+// validateGrantIn checks if the given grant is one of the allowed grants
+func validateGrantIn(grantName string, allowed map[string]string) error {
 	if grantName == "" {
 		return ErrInvalidGrant
 	}
 
-	if _, ok := grants[grantName]; !ok {
+	if _, ok := allowed[grantName]; !ok {
 		return ErrInvalidGrant
 	}
 
@@ -142,3 +142,91 @@ func TestMySQLController_Revoke(t *testing.T) {
 	}
 
 }
+
+func TestMySQLController_GrantToAccount(t *testing.T) {
+	c := createTestController()
+	local := Account{User: testUser, Host: "localhost"}
+
+	c.CreateAccount(local, testPassword)
+	defer c.DeleteAccount(local)
+
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+
+	err := c.GrantToAccount("select", testDB, local)
+	assert.NoError(t, err)
+
+	b, err := c.GrantExistsForAccount("select", testDB, local)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = c.GrantExists("select", testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.RevokeFromAccount("select", testDB, local)
+	assert.NoError(t, err)
+
+	b, err = c.GrantExistsForAccount("select", testDB, local)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.GrantAllToAccount(testDB, local)
+	assert.NoError(t, err)
+
+	err = c.RevokeAllFromAccount(testDB, local)
+	assert.NoError(t, err)
+
+	err = c.GrantAll(testDB, testUser)
+	assert.Equal(t, ErrUserDoesNotExist, err)
+}
+
+func Test_validateGrants(t *testing.T) {
+	privileges, err := validateGrants([]string{"select", "Show View"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT, SHOW VIEW", privileges)
+
+	_, err = validateGrants(nil)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+
+	_, err = validateGrants([]string{"SELECT", "SELECT; DROP DATABASE mysql"})
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestMySQLController_GrantMany(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.GrantMany(testDB, testUser, "select", "insert", "update")
+	assert.NoError(t, err)
+
+	for _, g := range []string{"select", "insert", "update"} {
+		b, err := c.GrantExists(g, testDB, testUser)
+		assert.NoError(t, err)
+		assert.True(t, b)
+	}
+
+	err = c.RevokeMany(testDB, testUser, "insert", "update")
+	assert.NoError(t, err)
+
+	p, err := c.ListGrants(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabasePrivileges{{Database: testDB, Privileges: []string{"SELECT"}}}, p.Databases)
+
+	// nothing is granted when one grant is invalid
+	err = c.GrantMany(testDB, testUser, "delete", "not-a-grant")
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+
+	b, err := c.GrantExists("delete", testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.GrantMany(testDB, testUser)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+
+	err = c.RevokeMany(testDB, testUser, "select", "super")
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
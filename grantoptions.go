@@ -0,0 +1,105 @@
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+)
+
+// GrantOption configures how database grants are given.
+type GrantOption func(*grantOptions)
+
+type grantOptions struct {
+	grantOption bool
+}
+
+// WithGrantOption returns a GrantOption that also gives the GRANT OPTION
+// privilege on the database, letting the account grant its own privileges
+// on it to other accounts.
+func WithGrantOption() GrantOption {
+	return func(o *grantOptions) {
+		o.grantOption = true
+	}
+}
+
+func newGrantOptions(opts []GrantOption) grantOptions {
+	var o grantOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// clause returns the clause appended to the GRANT statement.
+func (o grantOptions) clause() string {
+	if o.grantOption {
+		return " WITH GRANT OPTION"
+	}
+	return ""
+}
+
+// GrantOptionExists returns true if the given user can grant its privileges on the given database
+func (c *MySQLController) GrantOptionExists(dbName, username string) (bool, error) {
+	return c.GrantOptionExistsContext(context.Background(), dbName, username)
+}
+
+// GrantOptionExistsContext returns true if the given user can grant its privileges on the given database
+func (c *MySQLController) GrantOptionExistsContext(ctx context.Context, dbName, username string) (bool, error) {
+	return c.GrantOptionExistsForAccountContext(ctx, dbName, NewAccount(username))
+}
+
+// GrantOptionExistsForAccount returns true if the given account can grant its privileges on the given database
+func (c *MySQLController) GrantOptionExistsForAccount(dbName string, account Account) (bool, error) {
+	return c.GrantOptionExistsForAccountContext(context.Background(), dbName, account)
+}
+
+// GrantOptionExistsForAccountContext returns true if the given account can
+// grant its privileges on the given database, i.e. Grant_priv is set in
+// mysql.db for the database or a pattern matching it.
+func (c *MySQLController) GrantOptionExistsForAccountContext(ctx context.Context, dbName string, account Account) (bool, error) {
+	err := validateDBName(dbName)
+	if err != nil {
+		return false, fmt.Errorf("error validating database name: %w", err)
+	}
+
+	err = validateAccount(account)
+	if err != nil {
+		return false, fmt.Errorf("error validating account: %w", err)
+	}
+
+	ok, err := c.databasePrivilegeCovered(ctx, "Grant_priv", dbName, account)
+	if err != nil {
+		return false, fmt.Errorf("error checking if grant option exists: %w", err)
+	}
+	return ok, nil
+}
+
+// RevokeGrantOption stops the given user from granting its privileges on the given database
+func (c *MySQLController) RevokeGrantOption(dbName, username string) error {
+	return c.RevokeGrantOptionContext(context.Background(), dbName, username)
+}
+
+// RevokeGrantOptionContext stops the given user from granting its privileges on the given database
+func (c *MySQLController) RevokeGrantOptionContext(ctx context.Context, dbName, username string) error {
+	return c.RevokeGrantOptionFromAccountContext(ctx, dbName, NewAccount(username))
+}
+
+// RevokeGrantOptionFromAccount stops the given account from granting its privileges on the given database
+func (c *MySQLController) RevokeGrantOptionFromAccount(dbName string, account Account) error {
+	return c.RevokeGrantOptionFromAccountContext(context.Background(), dbName, account)
+}
+
+// RevokeGrantOptionFromAccountContext stops the given account from granting
+// its privileges on the given database. Its other privileges are kept.
+func (c *MySQLController) RevokeGrantOptionFromAccountContext(ctx context.Context, dbName string, account Account) error {
+	err := validateDBName(dbName)
+	if err != nil {
+		return fmt.Errorf("error validating database name: %w", err)
+	}
+
+	err = validateAccount(account)
+	if err != nil {
+		return fmt.Errorf("error validating account: %w", err)
+	}
+
+	return c.revokeOnDatabase(ctx, "GRANT OPTION", dbName, account)
+}
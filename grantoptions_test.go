@@ -0,0 +1,59 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_GrantOption(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.Grant("select", testDB, testUser)
+	assert.NoError(t, err)
+
+	b, err := c.GrantOptionExists(testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.Grant("insert", testDB, testUser, WithGrantOption())
+	assert.NoError(t, err)
+
+	b, err = c.GrantOptionExists(testDB, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	err = c.RevokeGrantOption(testDB, testUser)
+	assert.NoError(t, err)
+
+	b, err = c.GrantOptionExists(testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	// the other privileges are kept
+	b, err = c.GrantExists("insert", testDB, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	err = c.GrantAll(testDB, testUser, WithGrantOption())
+	assert.NoError(t, err)
+
+	b, err = c.GrantOptionExists(testDB, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = c.GrantOptionExists("", testUser)
+	assert.Error(t, err)
+
+	err = c.RevokeGrantOption(testDB, "")
+	assert.Error(t, err)
+}
+
+func Test_grantOptions(t *testing.T) {
+	assert.Equal(t, "", newGrantOptions(nil).clause())
+	assert.Equal(t, " WITH GRANT OPTION", newGrantOptions([]GrantOption{WithGrantOption()}).clause())
+}
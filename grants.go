@@ -20,3 +20,57 @@ var grants = map[string]string{
 	"TRIGGER":                 "Trigger_priv",
 	"UPDATE":                  "Update_priv",
 }
+
+// tableGrants are the grants that can be given on a single table, mapped to
+// their value in the Table_priv set of mysql.tables_priv.
+var tableGrants = map[string]string{
+	"ALTER":       "Alter",
+	"CREATE":      "Create",
+	"CREATE VIEW": "Create View",
+	"DELETE":      "Delete",
+	"DROP":        "Drop",
+	"INDEX":       "Index",
+	"INSERT":      "Insert",
+	"REFERENCES":  "References",
+	"SELECT":      "Select",
+	"SHOW VIEW":   "Show view",
+	"TRIGGER":     "Trigger",
+	"UPDATE":      "Update",
+}
+
+// columnGrants are the grants that can be given on table columns, mapped to
+// their value in the Column_priv set of mysql.columns_priv.
+var columnGrants = map[string]string{
+	"INSERT":     "Insert",
+	"REFERENCES": "References",
+	"SELECT":     "Select",
+	"UPDATE":     "Update",
+}
+
+// globalGrants are the server-level grants that can be given on *.*, mapped
+// to their column in mysql.user. They are kept apart from grants so that a
+// typo can never turn a per-database grant into a global one.
+var globalGrants = map[string]string{
+	"CREATE ROLE":        "Create_role_priv",
+	"CREATE TABLESPACE":  "Create_tablespace_priv",
+	"CREATE USER":        "Create_user_priv",
+	"DROP ROLE":          "Drop_role_priv",
+	"FILE":               "File_priv",
+	"GRANT OPTION":       "Grant_priv",
+	"PROCESS":            "Process_priv",
+	"RELOAD":             "Reload_priv",
+	"REPLICATION CLIENT": "Repl_client_priv",
+	"REPLICATION SLAVE":  "Repl_slave_priv",
+	"SHOW DATABASES":     "Show_db_priv",
+	"SHUTDOWN":           "Shutdown_priv",
+	"SUPER":              "Super_priv",
+}
+
+// privilegedGlobalGrants are the global grants that can only be given when the
+// controller is created WithPrivilegedGlobalGrants, as they hand out
+// administrative control: CREATE USER allows ALTER USER on any account
+// (including root), FILE reads and writes files on the server host,
+// REPLICATION SLAVE streams the binlog of every database (including the
+// ALTER USER ... IDENTIFIED statements this package runs) and SHUTDOWN stops
+// the server.
+var privilegedGlobalGrants = []string{"CREATE USER", "FILE", "GRANT OPTION", "REPLICATION SLAVE", "SHUTDOWN", "SUPER"}
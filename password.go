@@ -0,0 +1,229 @@
+package mysqlctl
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrPasswordPolicy = fmt.Errorf("password does not satisfy the password policy")
+
+// Password policy rules reported by PasswordPolicyError.
+const (
+	PasswordRuleMinLength  = "min_length"
+	PasswordRuleMaxLength  = "max_length"
+	PasswordRuleLower      = "lower"
+	PasswordRuleUpper      = "upper"
+	PasswordRuleDigit      = "digit"
+	PasswordRuleSymbol     = "symbol"
+	PasswordRuleDictionary = "dictionary"
+	PasswordRuleUsername   = "username"
+)
+
+// PasswordPolicyError is returned when a password violates a rule of the
+// password policy. It matches ErrPasswordPolicy with errors.Is.
+type PasswordPolicyError struct {
+	Rule    string
+	Message string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password policy: " + e.Message
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrPasswordPolicy
+}
+
+// PasswordValidator checks a password before it is set for a user.
+type PasswordValidator interface {
+	ValidatePassword(username, password string) error
+}
+
+// PasswordPolicy is a PasswordValidator built from common rules.
+// Zero values disable a rule.
+type PasswordPolicy struct {
+	// MinLength and MaxLength bound the number of characters.
+	MinLength int
+	MaxLength int
+
+	RequireLower  bool
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// Dictionary lists words that may not appear in the password,
+	// compared case-insensitively.
+	Dictionary []string
+
+	// DisallowUsername rejects passwords containing the username, forwards or
+	// reversed, compared case-insensitively.
+	DisallowUsername bool
+}
+
+// DefaultPasswordPolicy is a reasonable policy for service accounts.
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:        12,
+	MaxLength:        128,
+	RequireLower:     true,
+	RequireUpper:     true,
+	RequireDigit:     true,
+	DisallowUsername: true,
+}
+
+var _ PasswordValidator = PasswordPolicy{}
+
+// WithPasswordPolicy returns an Option that configures the MySQLController to
+// validate every plain text password it sets with the given validator.
+func WithPasswordPolicy(v PasswordValidator) Option {
+	return func(c *MySQLController) {
+		c.passwordPolicy = v
+	}
+}
+
+// ValidatePassword returns a *PasswordPolicyError for the first rule the
+// password violates.
+func (p PasswordPolicy) ValidatePassword(username, password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return &PasswordPolicyError{Rule: PasswordRuleMinLength, Message: fmt.Sprintf("password must be at least %d characters long", p.MinLength)}
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return &PasswordPolicyError{Rule: PasswordRuleMaxLength, Message: fmt.Sprintf("password must be at most %d characters long", p.MaxLength)}
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	if p.RequireLower && !lower {
+		return &PasswordPolicyError{Rule: PasswordRuleLower, Message: "password must contain a lowercase letter"}
+	}
+	if p.RequireUpper && !upper {
+		return &PasswordPolicyError{Rule: PasswordRuleUpper, Message: "password must contain an uppercase letter"}
+	}
+	if p.RequireDigit && !digit {
+		return &PasswordPolicyError{Rule: PasswordRuleDigit, Message: "password must contain a digit"}
+	}
+	if p.RequireSymbol && !symbol {
+		return &PasswordPolicyError{Rule: PasswordRuleSymbol, Message: "password must contain a symbol"}
+	}
+
+	folded := strings.ToLower(password)
+	for _, word := range p.Dictionary {
+		if word != "" && strings.Contains(folded, strings.ToLower(word)) {
+			return &PasswordPolicyError{Rule: PasswordRuleDictionary, Message: "password must not contain dictionary words"}
+		}
+	}
+
+	if p.DisallowUsername && username != "" {
+		name := strings.ToLower(username)
+		if strings.Contains(folded, name) || strings.Contains(folded, reverse(name)) {
+			return &PasswordPolicyError{Rule: PasswordRuleUsername, Message: "password must not contain the username"}
+		}
+	}
+
+	return nil
+}
+
+// DefaultPasswordCharset contains the letters and digits plus the symbols
+// that need no escaping in DSNs, URLs and shell commands.
+const DefaultPasswordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_."
+
+// PasswordGenerator generates cryptographically random passwords of Length
+// characters taken from Charset.
+type PasswordGenerator struct {
+	Length  int
+	Charset string
+}
+
+// DefaultPasswordGenerator is used unless WithPasswordGenerator is given.
+var DefaultPasswordGenerator = PasswordGenerator{Length: 24, Charset: DefaultPasswordCharset}
+
+// maxGenerateAttempts bounds how many passwords are generated while looking
+// for one that satisfies the password policy.
+const maxGenerateAttempts = 100
+
+// WithPasswordGenerator returns an Option that configures the MySQLController
+// to generate passwords with the given generator.
+func WithPasswordGenerator(g PasswordGenerator) Option {
+	return func(c *MySQLController) {
+		c.passwordGenerator = g
+	}
+}
+
+// Generate returns a new random password.
+func (g PasswordGenerator) Generate() (string, error) {
+	if g.Length <= 0 {
+		return "", fmt.Errorf("password length must be positive")
+	}
+
+	charset := []rune(g.Charset)
+	if len(charset) < 2 {
+		return "", fmt.Errorf("password charset must contain at least two characters")
+	}
+
+	max := big.NewInt(int64(len(charset)))
+	password := make([]rune, g.Length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("error generating password: %w", err)
+		}
+		password[i] = charset[n.Int64()]
+	}
+	return string(password), nil
+}
+
+// generatePassword generates a password for the given account that satisfies
+// the configured password policy.
+func (c *MySQLController) generatePassword(account Account) (string, error) {
+	g := c.passwordGenerator
+	if g == (PasswordGenerator{}) {
+		g = DefaultPasswordGenerator
+	}
+
+	var err error
+	for i := 0; i < maxGenerateAttempts; i++ {
+		var password string
+		password, err = g.Generate()
+		if err != nil {
+			return "", err
+		}
+
+		err = c.checkPassword(account, password)
+		if err == nil {
+			return password, nil
+		}
+	}
+	return "", fmt.Errorf("error generating a password that satisfies the policy: %w", err)
+}
+
+// checkPassword validates a plain text password for the given account against
+// the configured password policy, if any.
+func (c *MySQLController) checkPassword(account Account, password string) error {
+	if c.passwordPolicy == nil {
+		return nil
+	}
+	return c.passwordPolicy.ValidatePassword(account.User, password)
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
@@ -0,0 +1,96 @@
+package mysqlctl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertPasswordRule asserts that err is a *PasswordPolicyError for rule.
+func assertPasswordRule(t *testing.T, rule string, err error) {
+	t.Helper()
+	assert.ErrorIs(t, err, ErrPasswordPolicy)
+
+	var policyErr *PasswordPolicyError
+	if assert.True(t, errors.As(err, &policyErr)) {
+		assert.Equal(t, rule, policyErr.Rule)
+	}
+}
+
+func TestPasswordPolicy_ValidatePassword(t *testing.T) {
+	p := PasswordPolicy{}
+	assert.NoError(t, p.ValidatePassword(testUser, ""))
+
+	p = DefaultPasswordPolicy
+	assert.NoError(t, p.ValidatePassword(testUser, "Correct7Horse"))
+	assertPasswordRule(t, PasswordRuleMinLength, p.ValidatePassword(testUser, "Short7"))
+	assertPasswordRule(t, PasswordRuleMaxLength, p.ValidatePassword(testUser, "Aa1"+randomString(200)))
+	assertPasswordRule(t, PasswordRuleLower, p.ValidatePassword(testUser, "CORRECT7HORSE"))
+	assertPasswordRule(t, PasswordRuleUpper, p.ValidatePassword(testUser, "correct7horse"))
+	assertPasswordRule(t, PasswordRuleDigit, p.ValidatePassword(testUser, "CorrectHorse"))
+	assertPasswordRule(t, PasswordRuleUsername, p.ValidatePassword(testUser, "My7Test-User"))
+	assertPasswordRule(t, PasswordRuleUsername, p.ValidatePassword(testUser, "My7resu-tseT"))
+
+	p.RequireSymbol = true
+	assertPasswordRule(t, PasswordRuleSymbol, p.ValidatePassword(testUser, "Correct7Horse"))
+	assert.NoError(t, p.ValidatePassword(testUser, "Correct7Horse!"))
+
+	p.Dictionary = []string{"horse", "battery"}
+	assertPasswordRule(t, PasswordRuleDictionary, p.ValidatePassword(testUser, "Correct7HORSE!"))
+	assert.NoError(t, p.ValidatePassword(testUser, "Correct7Pony!"))
+
+	// length is counted in characters, not bytes
+	p = PasswordPolicy{MaxLength: 8}
+	assert.NoError(t, p.ValidatePassword(testUser, "пароль12"))
+}
+
+func Test_checkPassword(t *testing.T) {
+	c := &MySQLController{}
+	assert.NoError(t, c.checkPassword(NewAccount(testUser), "x"))
+
+	WithPasswordPolicy(DefaultPasswordPolicy)(c)
+	assertPasswordRule(t, PasswordRuleMinLength, c.checkPassword(NewAccount(testUser), "x"))
+}
+
+func TestPasswordGenerator_Generate(t *testing.T) {
+	password, err := DefaultPasswordGenerator.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, 24, len(password))
+	for _, r := range password {
+		assert.Contains(t, DefaultPasswordCharset, string(r))
+	}
+
+	other, err := DefaultPasswordGenerator.Generate()
+	assert.NoError(t, err)
+	assert.NotEqual(t, password, other)
+
+	password, err = PasswordGenerator{Length: 8, Charset: "äö"}.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, 8, len([]rune(password)))
+
+	_, err = PasswordGenerator{Length: 0, Charset: DefaultPasswordCharset}.Generate()
+	assert.Error(t, err)
+
+	_, err = PasswordGenerator{Length: 8, Charset: "a"}.Generate()
+	assert.Error(t, err)
+}
+
+func Test_generatePassword(t *testing.T) {
+	c := &MySQLController{}
+	password, err := c.generatePassword(NewAccount(testUser))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPasswordGenerator.Length, len(password))
+
+	WithPasswordPolicy(DefaultPasswordPolicy)(c)
+	WithPasswordGenerator(PasswordGenerator{Length: 12, Charset: DefaultPasswordCharset})(c)
+	for i := 0; i < 100; i++ {
+		password, err = c.generatePassword(NewAccount(testUser))
+		assert.NoError(t, err)
+		assert.NoError(t, DefaultPasswordPolicy.ValidatePassword(testUser, password))
+	}
+
+	WithPasswordGenerator(PasswordGenerator{Length: 12, Charset: "abc"})(c)
+	_, err = c.generatePassword(NewAccount(testUser))
+	assert.ErrorIs(t, err, ErrPasswordPolicy)
+}
@@ -0,0 +1,199 @@
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GrantPattern grants the given grant on every database matching the pattern to the given user
+func (c *MySQLController) GrantPattern(grantName, pattern, username string, opts ...GrantOption) error {
+	return c.GrantPatternContext(context.Background(), grantName, pattern, username, opts...)
+}
+
+// GrantPatternContext grants the given grant on every database matching the pattern to the given user
+func (c *MySQLController) GrantPatternContext(ctx context.Context, grantName, pattern, username string, opts ...GrantOption) error {
+	return c.GrantPatternToAccountContext(ctx, grantName, pattern, NewAccount(username), opts...)
+}
+
+// GrantPatternToAccount grants the given grant on every database matching the pattern to the given account
+func (c *MySQLController) GrantPatternToAccount(grantName, pattern string, account Account, opts ...GrantOption) error {
+	return c.GrantPatternToAccountContext(context.Background(), grantName, pattern, account, opts...)
+}
+
+// GrantPatternToAccountContext grants the given grant on every database
+// matching the pattern to the given account. In the pattern _ matches any
+// character and % any number of characters; use EscapeDatabasePattern for the
+// literal parts, e.g. EscapeDatabasePattern("tenant_1") + "%".
+func (c *MySQLController) GrantPatternToAccountContext(ctx context.Context, grantName, pattern string, account Account, opts ...GrantOption) error {
+	grantName, err := validatePatternGrant(grantName, pattern, account)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf("GRANT %s ON %s.* TO %s%s", grantName, quoteIdentifier(pattern), quoteAccount(account), newGrantOptions(opts).clause())
+	_, err = c.db.ExecContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error granting privileges: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// RevokePattern revokes the given grant on the pattern from the given user
+func (c *MySQLController) RevokePattern(grantName, pattern, username string) error {
+	return c.RevokePatternContext(context.Background(), grantName, pattern, username)
+}
+
+// RevokePatternContext revokes the given grant on the pattern from the given user
+func (c *MySQLController) RevokePatternContext(ctx context.Context, grantName, pattern, username string) error {
+	return c.RevokePatternFromAccountContext(ctx, grantName, pattern, NewAccount(username))
+}
+
+// RevokePatternFromAccount revokes the given grant on the pattern from the given account
+func (c *MySQLController) RevokePatternFromAccount(grantName, pattern string, account Account) error {
+	return c.RevokePatternFromAccountContext(context.Background(), grantName, pattern, account)
+}
+
+// RevokePatternFromAccountContext revokes the given grant on the pattern from
+// the given account. The pattern must be the one it was granted on.
+func (c *MySQLController) RevokePatternFromAccountContext(ctx context.Context, grantName, pattern string, account Account) error {
+	grantName, err := validatePatternGrant(grantName, pattern, account)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf("REVOKE %s ON %s.* FROM %s", grantName, quoteIdentifier(pattern), quoteAccount(account))
+	_, err = c.db.ExecContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error revoking privileges: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// PatternGrantExists returns true if the given grant exists on the pattern for the given user
+func (c *MySQLController) PatternGrantExists(grantName, pattern, username string) (bool, error) {
+	return c.PatternGrantExistsContext(context.Background(), grantName, pattern, username)
+}
+
+// PatternGrantExistsContext returns true if the given grant exists on the pattern for the given user
+func (c *MySQLController) PatternGrantExistsContext(ctx context.Context, grantName, pattern, username string) (bool, error) {
+	return c.PatternGrantExistsForAccountContext(ctx, grantName, pattern, NewAccount(username))
+}
+
+// PatternGrantExistsForAccount returns true if the given grant exists on the pattern for the given account
+func (c *MySQLController) PatternGrantExistsForAccount(grantName, pattern string, account Account) (bool, error) {
+	return c.PatternGrantExistsForAccountContext(context.Background(), grantName, pattern, account)
+}
+
+// PatternGrantExistsForAccountContext returns true if the given grant was
+// given on exactly this pattern to the given account. Use GrantExists to
+// check whether a database is covered by any pattern.
+func (c *MySQLController) PatternGrantExistsForAccountContext(ctx context.Context, grantName, pattern string, account Account) (bool, error) {
+	grantName, err := validatePatternGrant(grantName, pattern, account)
+	if err != nil {
+		return false, err
+	}
+
+	// the column name comes from the grants map, never from the caller
+	q := fmt.Sprintf("SELECT COUNT(*) FROM mysql.db WHERE Db = ? AND User = ? AND Host = ? AND %s = 'Y'", grants[grantName])
+	var count int
+	err = c.db.QueryRowContext(ctx, q, pattern, account.User, account.Host).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error checking if grant exists: %w", classifyError(err, nil))
+	}
+
+	return count > 0, nil
+}
+
+// literalDatabaseNames returns the values of the Db column of mysql.db that
+// grant on the database itself: its escaped name, and its raw name for grants
+// given before wildcards were escaped. The raw name is also a pattern, so
+// changing its grants affects every database it matches.
+func literalDatabaseNames(dbName string) (escaped, raw string) {
+	return EscapeDatabasePattern(dbName), dbName
+}
+
+// revokeOnDatabase revokes the privileges on the database from every
+// mysql.db row of the account granting on it. Without such a row the escaped
+// name is used, so that MySQL reports the missing grant.
+func (c *MySQLController) revokeOnDatabase(ctx context.Context, privileges, dbName string, account Account) error {
+	escaped, raw := literalDatabaseNames(dbName)
+	targets, err := c.queryStrings(ctx, "SELECT DISTINCT Db FROM mysql.db WHERE Db IN (?, ?) AND User = ? AND Host = ? ORDER BY Db", escaped, raw, account.User, account.Host)
+	if err != nil {
+		return fmt.Errorf("error listing database privileges: %w", err)
+	}
+	if len(targets) == 0 {
+		targets = []string{escaped}
+	}
+
+	for _, target := range targets {
+		q := fmt.Sprintf("REVOKE %s ON %s.* FROM %s", privileges, quoteIdentifier(target), quoteAccount(account))
+		_, err = c.db.ExecContext(ctx, q)
+		if err != nil {
+			return fmt.Errorf("error revoking privileges: %w", classifyError(err, nil))
+		}
+	}
+	return nil
+}
+
+// databasePrivilegeCovered returns true if the mysql.db privilege column is
+// set for the account on the database. The rows for the database itself (see
+// literalDatabaseNames) take precedence over the patterns matching it, as they
+// do in MySQL.
+func (c *MySQLController) databasePrivilegeCovered(ctx context.Context, column, dbName string, account Account) (bool, error) {
+	escaped, raw := literalDatabaseNames(dbName)
+	q := fmt.Sprintf("SELECT Db IN (?, ?), %s = 'Y' FROM mysql.db WHERE User = ? AND Host = ? AND ? LIKE Db", column)
+	rows, err := c.db.QueryContext(ctx, q, escaped, raw, account.User, account.Host, dbName)
+	if err != nil {
+		return false, classifyError(err, nil)
+	}
+	defer rows.Close()
+
+	var literal, literalSet, patternSet bool
+	for rows.Next() {
+		var exact, set bool
+		err = rows.Scan(&exact, &set)
+		if err != nil {
+			return false, err
+		}
+		if exact {
+			literal = true
+			literalSet = literalSet || set
+		} else {
+			patternSet = patternSet || set
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return false, err
+	}
+
+	if literal {
+		return literalSet, nil
+	}
+	return patternSet, nil
+}
+
+// validatePatternGrant validates a grant on a database pattern and returns
+// the normalized grant name. Patterns matching a system database are refused.
+func validatePatternGrant(grantName, pattern string, account Account) (string, error) {
+	if pattern == "" {
+		return "", fmt.Errorf("error validating pattern: pattern cannot be empty")
+	}
+	for _, dbName := range baseDBs {
+		if matchDatabasePattern(pattern, dbName) {
+			return "", fmt.Errorf("error validating pattern: %v matches the %v database", pattern, dbName)
+		}
+	}
+
+	err := validateAccount(account)
+	if err != nil {
+		return "", fmt.Errorf("error validating account: %w", err)
+	}
+
+	grantName = strings.ToUpper(grantName)
+	err = validateGrant(grantName)
+	if err != nil {
+		return "", fmt.Errorf("error validating grant: %w", err)
+	}
+	return grantName, nil
+}
@@ -0,0 +1,139 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_GrantPattern(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	pattern := EscapeDatabasePattern("test_db") + "%"
+
+	err := c.GrantPattern("select", pattern, testUser)
+	assert.NoError(t, err)
+
+	b, err := c.PatternGrantExists("select", pattern, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	for _, dbName := range []string{"test_db", "test_db_2"} {
+		b, err = c.GrantExists("select", dbName, testUser)
+		assert.NoError(t, err)
+		assert.True(t, b, dbName)
+	}
+
+	// the escaped _ is not a wildcard
+	b, err = c.GrantExists("select", "testXdb", testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	b, err = c.GrantExists("insert", "test_db", testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.RevokePattern("select", pattern, testUser)
+	assert.NoError(t, err)
+
+	b, err = c.GrantExists("select", "test_db", testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	for _, p := range []string{"", "%", "m%", "%_schema", "sy_"} {
+		err = c.GrantPattern("select", p, testUser)
+		assert.Error(t, err, p)
+	}
+
+	err = c.GrantPattern("super", pattern, testUser)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestMySQLController_GrantEscapesWildcards(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.Grant("select", "test_db", testUser)
+	assert.NoError(t, err)
+	defer c.Revoke("select", "test_db", testUser)
+
+	b, err := c.GrantExists("select", "test_db", testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = c.GrantExists("select", "testXdb", testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	p, err := c.ListGrants(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabasePrivileges{{Database: `test\_db`, Privileges: []string{"SELECT"}}}, p.Databases)
+
+	// the grant on the database itself takes precedence over patterns
+	err = c.GrantPattern("insert", "test%", testUser)
+	assert.NoError(t, err)
+	defer c.RevokePattern("insert", "test%", testUser)
+
+	b, err = c.GrantExists("insert", "test_db", testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	b, err = c.GrantExists("insert", "test-db", testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+}
+
+func TestMySQLController_UnescapedGrants(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase("test_db")
+	defer c.DeleteDatabase("test_db")
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	// grants given before wildcards were escaped
+	_, err := c.db.Exec("GRANT SELECT, INSERT, UPDATE ON `test_db`.* TO 'test-user'@'%'")
+	assert.NoError(t, err)
+	defer c.db.Exec("REVOKE ALL PRIVILEGES ON `test_db`.* FROM 'test-user'@'%'")
+
+	access, err := c.DatabaseUsers("test_db")
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabaseAccess{{Account: NewAccount(testUser), Privileges: []string{"INSERT", "SELECT", "UPDATE"}}}, access)
+
+	err = c.Revoke("update", "test_db", testUser)
+	assert.NoError(t, err)
+
+	b, err := c.GrantExists("update", "test_db", testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	changes, err := c.SetGrants("test_db", testUser, []string{"SELECT", "DELETE"})
+	assert.NoError(t, err)
+	assert.Equal(t, GrantChanges{Granted: []string{"DELETE"}, Revoked: []string{"INSERT"}}, changes)
+
+	access, err = c.DatabaseUsers("test_db")
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabaseAccess{{Account: NewAccount(testUser), Privileges: []string{"DELETE", "SELECT"}}}, access)
+
+	b, err = c.GrantExists("insert", "test_db", testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.RevokeAll("test_db", testUser)
+	assert.NoError(t, err)
+
+	access, err = c.DatabaseUsers("test_db")
+	assert.NoError(t, err)
+	assert.Empty(t, access)
+}
+
+func Test_literalDatabaseNames(t *testing.T) {
+	escaped, raw := literalDatabaseNames("test_db")
+	assert.Equal(t, `test\_db`, escaped)
+	assert.Equal(t, "test_db", raw)
+
+	escaped, raw = literalDatabaseNames(testDB)
+	assert.Equal(t, escaped, raw)
+}
@@ -0,0 +1,451 @@
+package mysqlctl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Privileges is a structured view of every privilege an account holds.
+type Privileges struct {
+	// Global are the privileges on *.*, including dynamic privileges.
+	Global    []string
+	Databases []DatabasePrivileges
+	Tables    []TablePrivileges
+	Columns   []ColumnPrivileges
+	Routines  []RoutinePrivileges
+	// Roles are the roles granted to the account.
+	Roles []Account
+}
+
+// DatabasePrivileges are the privileges on a database (`db`.*). Database is
+// the pattern stored in mysql.db: wildcards in literal database names are
+// escaped (see EscapeDatabasePattern).
+type DatabasePrivileges struct {
+	Database   string
+	Privileges []string
+}
+
+// TablePrivileges are the privileges on a single table.
+type TablePrivileges struct {
+	Database   string
+	Table      string
+	Privileges []string
+}
+
+// ColumnPrivileges are the privileges on a single column of a table.
+type ColumnPrivileges struct {
+	Database   string
+	Table      string
+	Column     string
+	Privileges []string
+}
+
+// RoutinePrivileges are the privileges on a stored procedure or function.
+type RoutinePrivileges struct {
+	Database   string
+	Routine    string
+	Type       string
+	Privileges []string
+}
+
+// ListGrants returns every privilege of the given user
+func (c *MySQLController) ListGrants(username string) (Privileges, error) {
+	return c.ListGrantsContext(context.Background(), username)
+}
+
+// ListGrantsContext returns every privilege of the given user
+func (c *MySQLController) ListGrantsContext(ctx context.Context, username string) (Privileges, error) {
+	return c.ListGrantsForAccountContext(ctx, NewAccount(username))
+}
+
+// ListGrantsForAccount returns every privilege of the given account
+func (c *MySQLController) ListGrantsForAccount(account Account) (Privileges, error) {
+	return c.ListGrantsForAccountContext(context.Background(), account)
+}
+
+// ListGrantsForAccountContext returns every privilege of the given account,
+// read from the mysql grant tables. Privileges inherited through roles are not
+// expanded; the roles themselves are listed in Roles.
+func (c *MySQLController) ListGrantsForAccountContext(ctx context.Context, account Account) (Privileges, error) {
+	err := validateAccount(account)
+	if err != nil {
+		return Privileges{}, fmt.Errorf("error validating account: %w", err)
+	}
+
+	var p Privileges
+
+	rows, err := c.queryPrivilegeRows(ctx, "SELECT * FROM mysql.user WHERE User = ? AND Host = ?", account.User, account.Host)
+	if err != nil {
+		return Privileges{}, fmt.Errorf("error listing global privileges: %w", err)
+	}
+	if len(rows) == 0 {
+		return Privileges{}, ErrUserDoesNotExist
+	}
+	p.Global = rows[0].privileges(globalPrivilegeColumns)
+
+	dynamic, err := c.queryStrings(ctx, "SELECT PRIV FROM mysql.global_grants WHERE USER = ? AND HOST = ?", account.User, account.Host)
+	if err != nil && !errors.Is(err, ErrTableDoesNotExist) {
+		return Privileges{}, fmt.Errorf("error listing dynamic privileges: %w", err)
+	}
+	p.Global = append(p.Global, dynamic...)
+	sort.Strings(p.Global)
+
+	rows, err = c.queryPrivilegeRows(ctx, "SELECT * FROM mysql.db WHERE User = ? AND Host = ? ORDER BY Db", account.User, account.Host)
+	if err != nil {
+		return Privileges{}, fmt.Errorf("error listing database privileges: %w", err)
+	}
+	for _, row := range rows {
+		p.Databases = append(p.Databases, DatabasePrivileges{
+			Database:   row.values["Db"],
+			Privileges: row.privileges(databasePrivilegeColumns),
+		})
+	}
+
+	p.Tables, err = c.listTablePrivileges(ctx, account)
+	if err != nil {
+		return Privileges{}, err
+	}
+
+	p.Columns, err = c.listColumnPrivileges(ctx, account)
+	if err != nil {
+		return Privileges{}, err
+	}
+
+	p.Routines, err = c.listRoutinePrivileges(ctx, account)
+	if err != nil {
+		return Privileges{}, err
+	}
+
+	p.Roles, err = c.listAccountRoles(ctx, account)
+	if err != nil {
+		return Privileges{}, err
+	}
+
+	return p, nil
+}
+
+func (c *MySQLController) listTablePrivileges(ctx context.Context, account Account) ([]TablePrivileges, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT Db, Table_name, Table_priv FROM mysql.tables_priv WHERE User = ? AND Host = ? ORDER BY Db, Table_name", account.User, account.Host)
+	if err != nil {
+		return nil, fmt.Errorf("error listing table privileges: %w", classifyError(err, nil))
+	}
+	defer rows.Close()
+
+	var tables []TablePrivileges
+	for rows.Next() {
+		var t TablePrivileges
+		var set string
+		err = rows.Scan(&t.Database, &t.Table, &set)
+		if err != nil {
+			return nil, err
+		}
+		// rows of tables with only column grants have an empty Table_priv
+		if set == "" {
+			continue
+		}
+		t.Privileges = privilegesFromSet(set)
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+func (c *MySQLController) listColumnPrivileges(ctx context.Context, account Account) ([]ColumnPrivileges, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT Db, Table_name, Column_name, Column_priv FROM mysql.columns_priv WHERE User = ? AND Host = ? ORDER BY Db, Table_name, Column_name", account.User, account.Host)
+	if err != nil {
+		return nil, fmt.Errorf("error listing column privileges: %w", classifyError(err, nil))
+	}
+	defer rows.Close()
+
+	var columns []ColumnPrivileges
+	for rows.Next() {
+		var col ColumnPrivileges
+		var set string
+		err = rows.Scan(&col.Database, &col.Table, &col.Column, &set)
+		if err != nil {
+			return nil, err
+		}
+		col.Privileges = privilegesFromSet(set)
+		columns = append(columns, col)
+	}
+	return columns, rows.Err()
+}
+
+func (c *MySQLController) listRoutinePrivileges(ctx context.Context, account Account) ([]RoutinePrivileges, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT Db, Routine_name, Routine_type, Proc_priv FROM mysql.procs_priv WHERE User = ? AND Host = ? ORDER BY Db, Routine_name", account.User, account.Host)
+	if err != nil {
+		return nil, fmt.Errorf("error listing routine privileges: %w", classifyError(err, nil))
+	}
+	defer rows.Close()
+
+	var routines []RoutinePrivileges
+	for rows.Next() {
+		var r RoutinePrivileges
+		var set string
+		err = rows.Scan(&r.Database, &r.Routine, &r.Type, &set)
+		if err != nil {
+			return nil, err
+		}
+		r.Privileges = privilegesFromSet(set)
+		routines = append(routines, r)
+	}
+	return routines, rows.Err()
+}
+
+// listAccountRoles returns the roles granted to the account. Servers without
+// roles (MySQL 5.7) have no mysql.role_edges table and return none.
+func (c *MySQLController) listAccountRoles(ctx context.Context, account Account) ([]Account, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT FROM_USER, FROM_HOST FROM mysql.role_edges WHERE TO_USER = ? AND TO_HOST = ? ORDER BY FROM_USER, FROM_HOST", account.User, account.Host)
+	if err != nil {
+		err = classifyError(err, nil)
+		if errors.Is(err, ErrTableDoesNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []Account
+	for rows.Next() {
+		var role Account
+		err = rows.Scan(&role.User, &role.Host)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// privilegeRow is a row of one of the mysql grant tables with one *_priv
+// column per privilege.
+type privilegeRow struct {
+	values map[string]string
+}
+
+// privileges returns the privileges whose column is 'Y', using byColumn to
+// name them.
+func (r privilegeRow) privileges(byColumn map[string]string) []string {
+	var privileges []string
+	for column, value := range r.values {
+		if name, ok := byColumn[column]; ok && value == "Y" {
+			privileges = append(privileges, name)
+		}
+	}
+	sort.Strings(privileges)
+	return privileges
+}
+
+// queryPrivilegeRows runs a SELECT * on a grant table whose columns differ
+// between server versions.
+func (c *MySQLController) queryPrivilegeRows(ctx context.Context, q string, args ...interface{}) ([]privilegeRow, error) {
+	rows, err := c.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, classifyError(err, nil)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []privilegeRow
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		err = rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+
+		row := privilegeRow{values: make(map[string]string, len(columns))}
+		for i, column := range columns {
+			row.values[column] = values[i].String
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// queryStrings returns the single string column of every row.
+func (c *MySQLController) queryStrings(ctx context.Context, q string, args ...interface{}) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, classifyError(err, nil)
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var s string
+		err = rows.Scan(&s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+// privilegesFromSet converts a SET value of mysql.tables_priv,
+// mysql.columns_priv or mysql.procs_priv (e.g. "Select,Show view,Grant") to
+// privilege names.
+func privilegesFromSet(set string) []string {
+	var privileges []string
+	for _, value := range strings.Split(set, ",") {
+		switch value {
+		case "":
+		case "Grant":
+			privileges = append(privileges, "GRANT OPTION")
+		default:
+			privileges = append(privileges, strings.ToUpper(value))
+		}
+	}
+	sort.Strings(privileges)
+	return privileges
+}
+
+// byColumn inverts maps of privilege names to grant table columns.
+func byColumn(maps ...map[string]string) map[string]string {
+	inverted := map[string]string{}
+	for _, m := range maps {
+		for name, column := range m {
+			inverted[column] = name
+		}
+	}
+	return inverted
+}
+
+var (
+	// databasePrivilegeColumns names the privilege columns of mysql.db.
+	databasePrivilegeColumns = byColumn(grants, map[string]string{"GRANT OPTION": "Grant_priv"})
+	// globalPrivilegeColumns names the privilege columns of mysql.user.
+	globalPrivilegeColumns = byColumn(grants, globalGrants)
+)
+
+// DatabaseAccess is the access an account has to a single database.
+type DatabaseAccess struct {
+	Account Account
+	// Privileges are the privileges on the whole database (`db`.*).
+	Privileges []string
+	Tables     []TablePrivileges
+	Columns    []ColumnPrivileges
+}
+
+// DatabaseUsers returns every account with any privilege on the database.
+func (c *MySQLController) DatabaseUsers(dbName string) ([]DatabaseAccess, error) {
+	return c.DatabaseUsersContext(context.Background(), dbName)
+}
+
+// DatabaseUsersContext returns every account with any privilege on the
+// database, from mysql.db, mysql.tables_priv and mysql.columns_priv, sorted by
+// account. Reserved users and pattern grants matching the database are left
+// out.
+func (c *MySQLController) DatabaseUsersContext(ctx context.Context, dbName string) ([]DatabaseAccess, error) {
+	err := validateDBName(dbName)
+	if err != nil {
+		return nil, fmt.Errorf("error validating database name: %w", err)
+	}
+
+	access := map[Account]*DatabaseAccess{}
+	get := func(account Account) *DatabaseAccess {
+		a, ok := access[account]
+		if !ok {
+			a = &DatabaseAccess{Account: account}
+			access[account] = a
+		}
+		return a
+	}
+
+	escaped, raw := literalDatabaseNames(dbName)
+	rows, err := c.queryPrivilegeRows(ctx, "SELECT * FROM mysql.db WHERE Db IN (?, ?)", escaped, raw)
+	if err != nil {
+		return nil, fmt.Errorf("error listing database privileges: %w", err)
+	}
+	for _, row := range rows {
+		a := get(Account{User: row.values["User"], Host: row.values["Host"]})
+		a.Privileges = uniqueGrants(append(a.Privileges, row.privileges(databasePrivilegeColumns)...))
+	}
+
+	err = c.scanDatabaseTablePrivileges(ctx, dbName, get)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.scanDatabaseColumnPrivileges(ctx, dbName, get)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []Account
+	for account := range access {
+		accounts = append(accounts, account)
+	}
+	accounts = filterAccounts(accounts)
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].User != accounts[j].User {
+			return accounts[i].User < accounts[j].User
+		}
+		return accounts[i].Host < accounts[j].Host
+	})
+
+	result := make([]DatabaseAccess, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, *access[account])
+	}
+	return result, nil
+}
+
+func (c *MySQLController) scanDatabaseTablePrivileges(ctx context.Context, dbName string, get func(Account) *DatabaseAccess) error {
+	rows, err := c.db.QueryContext(ctx, "SELECT User, Host, Table_name, Table_priv FROM mysql.tables_priv WHERE Db = ? ORDER BY Table_name", dbName)
+	if err != nil {
+		return fmt.Errorf("error listing table privileges: %w", classifyError(err, nil))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var account Account
+		t := TablePrivileges{Database: dbName}
+		var set string
+		err = rows.Scan(&account.User, &account.Host, &t.Table, &set)
+		if err != nil {
+			return err
+		}
+		if set == "" {
+			continue
+		}
+		t.Privileges = privilegesFromSet(set)
+		a := get(account)
+		a.Tables = append(a.Tables, t)
+	}
+	return rows.Err()
+}
+
+func (c *MySQLController) scanDatabaseColumnPrivileges(ctx context.Context, dbName string, get func(Account) *DatabaseAccess) error {
+	rows, err := c.db.QueryContext(ctx, "SELECT User, Host, Table_name, Column_name, Column_priv FROM mysql.columns_priv WHERE Db = ? ORDER BY Table_name, Column_name", dbName)
+	if err != nil {
+		return fmt.Errorf("error listing column privileges: %w", classifyError(err, nil))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var account Account
+		col := ColumnPrivileges{Database: dbName}
+		var set string
+		err = rows.Scan(&account.User, &account.Host, &col.Table, &col.Column, &set)
+		if err != nil {
+			return err
+		}
+		col.Privileges = privilegesFromSet(set)
+		a := get(account)
+		a.Columns = append(a.Columns, col)
+	}
+	return rows.Err()
+}
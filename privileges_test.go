@@ -0,0 +1,108 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_ListGrants(t *testing.T) {
+	c := createTestController()
+	_, err := c.ListGrants(testUser)
+	assert.Equal(t, ErrUserDoesNotExist, err)
+
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+
+	_, err = c.db.Exec("CREATE TABLE `test-db`.users (id INT, name VARCHAR(255), email VARCHAR(255))")
+	assert.NoError(t, err)
+
+	p, err := c.ListGrants(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, Privileges{}, p)
+
+	assert.NoError(t, c.GrantGlobal("process", testUser))
+	assert.NoError(t, c.Grant("select", testDB, testUser))
+	assert.NoError(t, c.Grant("insert", testDB, testUser))
+	assert.NoError(t, c.GrantTable("update", testDB, "users", testUser))
+	assert.NoError(t, c.GrantColumns("references", testDB, "users", []string{"email"}, testUser))
+
+	p, err = c.ListGrants(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PROCESS"}, p.Global)
+	assert.Equal(t, []DatabasePrivileges{{Database: testDB, Privileges: []string{"INSERT", "SELECT"}}}, p.Databases)
+	assert.Equal(t, []TablePrivileges{{Database: testDB, Table: "users", Privileges: []string{"UPDATE"}}}, p.Tables)
+	assert.Equal(t, []ColumnPrivileges{{Database: testDB, Table: "users", Column: "email", Privileges: []string{"REFERENCES"}}}, p.Columns)
+	assert.Empty(t, p.Routines)
+	assert.Empty(t, p.Roles)
+}
+
+func Test_privilegesFromSet(t *testing.T) {
+	assert.Nil(t, privilegesFromSet(""))
+	assert.Equal(t, []string{"CREATE VIEW", "GRANT OPTION", "SELECT", "SHOW VIEW"}, privilegesFromSet("Select,Create View,Show view,Grant"))
+	assert.Equal(t, []string{"ALTER ROUTINE", "EXECUTE"}, privilegesFromSet("Execute,Alter Routine"))
+
+	for g, value := range tableGrants {
+		assert.Equal(t, []string{g}, privilegesFromSet(value))
+	}
+}
+
+func Test_privilegeRow_privileges(t *testing.T) {
+	row := privilegeRow{values: map[string]string{
+		"Host":          "%",
+		"User":          testUser,
+		"Select_priv":   "Y",
+		"Insert_priv":   "N",
+		"Process_priv":  "Y",
+		"Grant_priv":    "Y",
+		"Unknown_priv":  "Y",
+		"max_questions": "0",
+	}}
+	assert.Equal(t, []string{"GRANT OPTION", "PROCESS", "SELECT"}, row.privileges(globalPrivilegeColumns))
+	assert.Equal(t, []string{"GRANT OPTION", "SELECT"}, row.privileges(databasePrivilegeColumns))
+}
+
+func TestMySQLController_DatabaseUsers(t *testing.T) {
+	c := createTestController()
+	local := Account{User: testUser, Host: "localhost"}
+
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+
+	users, err := c.DatabaseUsers(testDB)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	c.CreateAccount(local, testPassword)
+	defer c.DeleteAccount(local)
+
+	_, err = c.db.Exec("CREATE TABLE `test-db`.users (id INT, name VARCHAR(255), email VARCHAR(255))")
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Grant("select", testDB, testUser))
+	assert.NoError(t, c.GrantTableToAccount("insert", testDB, "users", local))
+	assert.NoError(t, c.GrantColumnsToAccount("update", testDB, "users", []string{"name"}, local))
+
+	users, err = c.DatabaseUsers(testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabaseAccess{
+		{
+			Account:    NewAccount(testUser),
+			Privileges: []string{"SELECT"},
+		},
+		{
+			Account: local,
+			Tables:  []TablePrivileges{{Database: testDB, Table: "users", Privileges: []string{"INSERT"}}},
+			Columns: []ColumnPrivileges{{Database: testDB, Table: "users", Column: "name", Privileges: []string{"UPDATE"}}},
+		},
+	}, users)
+
+	_, err = c.DatabaseUsers("")
+	assert.Error(t, err)
+}
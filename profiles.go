@@ -0,0 +1,151 @@
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrNoMatchingProfile = fmt.Errorf("no matching profile")
+)
+
+// Profile is a named set of database grants.
+type Profile struct {
+	Name   string
+	Grants []string
+}
+
+var (
+	// ProfileReadOnly can read tables and views.
+	ProfileReadOnly = Profile{
+		Name:   "read-only",
+		Grants: []string{"SELECT", "SHOW VIEW"},
+	}
+	// ProfileReadWrite can also modify rows.
+	ProfileReadWrite = Profile{
+		Name:   "read-write",
+		Grants: []string{"DELETE", "INSERT", "LOCK TABLES", "SELECT", "SHOW VIEW", "UPDATE"},
+	}
+	// ProfileDDL can also change the schema.
+	ProfileDDL = Profile{
+		Name: "ddl",
+		Grants: []string{
+			"ALTER", "CREATE", "CREATE TEMPORARY TABLES", "CREATE VIEW", "DELETE", "DROP", "INDEX",
+			"INSERT", "LOCK TABLES", "REFERENCES", "SELECT", "SHOW VIEW", "TRIGGER", "UPDATE",
+		},
+	}
+	// ProfileOwner holds every database grant.
+	ProfileOwner = Profile{
+		Name:   "owner",
+		Grants: grantNames(grants),
+	}
+)
+
+// Profiles are the predefined profiles, from the least to the most privileged.
+var Profiles = []Profile{ProfileReadOnly, ProfileReadWrite, ProfileDDL, ProfileOwner}
+
+// ApplyProfile grants every grant of the profile on the given database to the given user
+func (c *MySQLController) ApplyProfile(dbName, username string, profile Profile) error {
+	return c.ApplyProfileContext(context.Background(), dbName, username, profile)
+}
+
+// ApplyProfileContext grants every grant of the profile on the given database to the given user
+func (c *MySQLController) ApplyProfileContext(ctx context.Context, dbName, username string, profile Profile) error {
+	return c.ApplyProfileToAccountContext(ctx, dbName, NewAccount(username), profile)
+}
+
+// ApplyProfileToAccount grants every grant of the profile on the given database to the given account
+func (c *MySQLController) ApplyProfileToAccount(dbName string, account Account, profile Profile) error {
+	return c.ApplyProfileToAccountContext(context.Background(), dbName, account, profile)
+}
+
+// ApplyProfileToAccountContext grants every grant of the profile on the given
+// database to the given account. Grants the account already holds are kept.
+func (c *MySQLController) ApplyProfileToAccountContext(ctx context.Context, dbName string, account Account, profile Profile) error {
+	return c.grantPrivileges(ctx, profile.Grants, dbName, account)
+}
+
+// DetectProfile returns the profile matching the grants of the given user on the given database
+func (c *MySQLController) DetectProfile(dbName, username string) (Profile, error) {
+	return c.DetectProfileContext(context.Background(), dbName, username)
+}
+
+// DetectProfileContext returns the profile matching the grants of the given user on the given database
+func (c *MySQLController) DetectProfileContext(ctx context.Context, dbName, username string) (Profile, error) {
+	return c.DetectProfileForAccountContext(ctx, dbName, NewAccount(username))
+}
+
+// DetectProfileForAccount returns the profile matching the grants of the given account on the given database
+func (c *MySQLController) DetectProfileForAccount(dbName string, account Account) (Profile, error) {
+	return c.DetectProfileForAccountContext(context.Background(), dbName, account)
+}
+
+// DetectProfileForAccountContext returns the predefined profile whose grants
+// are exactly the grants of the given account on the given database, or
+// ErrNoMatchingProfile.
+func (c *MySQLController) DetectProfileForAccountContext(ctx context.Context, dbName string, account Account) (Profile, error) {
+	current, err := c.databaseGrants(ctx, dbName, account)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	for _, profile := range Profiles {
+		if profile.matches(current) {
+			return profile, nil
+		}
+	}
+	return Profile{}, ErrNoMatchingProfile
+}
+
+// matches returns true if the sorted grants are exactly the grants of the profile.
+func (p Profile) matches(grants []string) bool {
+	if len(p.Grants) != len(grants) {
+		return false
+	}
+
+	own := append([]string(nil), p.Grants...)
+	sort.Strings(own)
+	for i := range own {
+		if own[i] != grants[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// databaseGrants returns the sorted grants of the account on the database,
+// read from its mysql.db rows (see literalDatabaseNames).
+func (c *MySQLController) databaseGrants(ctx context.Context, dbName string, account Account) ([]string, error) {
+	err := validateDBName(dbName)
+	if err != nil {
+		return nil, fmt.Errorf("error validating database name: %w", err)
+	}
+
+	err = validateAccount(account)
+	if err != nil {
+		return nil, fmt.Errorf("error validating account: %w", err)
+	}
+
+	escaped, raw := literalDatabaseNames(dbName)
+	rows, err := c.queryPrivilegeRows(ctx, "SELECT * FROM mysql.db WHERE Db IN (?, ?) AND User = ? AND Host = ?", escaped, raw, account.User, account.Host)
+	if err != nil {
+		return nil, fmt.Errorf("error listing database privileges: %w", err)
+	}
+
+	var current []string
+	for _, row := range rows {
+		current = append(current, row.privileges(byColumn(grants))...)
+	}
+	return uniqueGrants(current), nil
+}
+
+// grantNames returns the sorted names of the grants.
+func grantNames(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
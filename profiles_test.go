@@ -0,0 +1,75 @@
+package mysqlctl
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_ApplyProfile(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	_, err := c.DetectProfile(testDB, testUser)
+	assert.ErrorIs(t, err, ErrNoMatchingProfile)
+
+	for _, profile := range Profiles {
+		err = c.ApplyProfile(testDB, testUser, profile)
+		assert.NoError(t, err)
+
+		for _, g := range profile.Grants {
+			b, err := c.GrantExists(g, testDB, testUser)
+			assert.NoError(t, err)
+			assert.True(t, b)
+		}
+
+		p, err := c.DetectProfile(testDB, testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, profile.Name, p.Name)
+	}
+
+	err = c.Revoke("select", testDB, testUser)
+	assert.NoError(t, err)
+
+	_, err = c.DetectProfile(testDB, testUser)
+	assert.ErrorIs(t, err, ErrNoMatchingProfile)
+
+	err = c.RevokeAll(testDB, testUser)
+	assert.NoError(t, err)
+
+	err = c.GrantAll(testDB, testUser)
+	assert.NoError(t, err)
+
+	p, err := c.DetectProfile(testDB, testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, ProfileOwner.Name, p.Name)
+
+	err = c.ApplyProfile(testDB, testUser, Profile{Name: "bad", Grants: []string{"SELECT", "SUPER"}})
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestProfiles(t *testing.T) {
+	for i, profile := range Profiles {
+		assert.True(t, sort.StringsAreSorted(profile.Grants), profile.Name)
+
+		for _, g := range profile.Grants {
+			assert.NoError(t, validateGrant(g), profile.Name)
+		}
+
+		// every profile includes the previous one
+		if i > 0 {
+			for _, g := range Profiles[i-1].Grants {
+				assert.Contains(t, profile.Grants, g, profile.Name)
+			}
+		}
+	}
+
+	assert.Len(t, ProfileOwner.Grants, len(grants))
+	assert.True(t, ProfileReadOnly.matches([]string{"SELECT", "SHOW VIEW"}))
+	assert.False(t, ProfileReadOnly.matches([]string{"SELECT"}))
+	assert.False(t, ProfileReadOnly.matches([]string{"INSERT", "SELECT"}))
+}
@@ -0,0 +1,404 @@
+package mysqlctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// QuotaController limits the size of databases by revoking the write grants
+// of their users while they are over quota.
+type QuotaController interface {
+	SetQuota(dbName string, limit int64, opts ...QuotaOption) error
+	RemoveQuota(dbName string) error
+	Quotas() map[string]int64
+	CheckQuota(dbName string) (QuotaStatus, error)
+	EnforceQuota(dbName string) (QuotaStatus, error)
+	EnforceQuotas() ([]QuotaStatus, error)
+}
+
+// QuotaControllerContext is the context-aware counterpart of QuotaController.
+type QuotaControllerContext interface {
+	RemoveQuotaContext(ctx context.Context, dbName string) error
+	CheckQuotaContext(ctx context.Context, dbName string) (QuotaStatus, error)
+	EnforceQuotaContext(ctx context.Context, dbName string) (QuotaStatus, error)
+	EnforceQuotasContext(ctx context.Context) ([]QuotaStatus, error)
+}
+
+var (
+	_ QuotaController        = &MySQLController{}
+	_ QuotaControllerContext = &MySQLController{}
+)
+
+var (
+	ErrNoQuota = fmt.Errorf("database has no quota")
+)
+
+// quotaWriteGrants are the grants revoked from the users of a database over
+// its quota. DELETE and DROP are kept so that space can be freed.
+var quotaWriteGrants = []string{
+	"ALTER", "ALTER ROUTINE", "CREATE", "CREATE ROUTINE", "CREATE TEMPORARY TABLES",
+	"CREATE VIEW", "EVENT", "INDEX", "INSERT", "TRIGGER", "UPDATE",
+}
+
+// QuotaStatus is the usage of a database with a quota.
+type QuotaStatus struct {
+	Database string
+	Limit    int64
+	Size     int64
+	// Revoked are the write grants revoked from each account because the
+	// database is over quota.
+	Revoked map[Account][]string
+}
+
+// Exceeded returns true if the database is larger than its limit.
+func (s QuotaStatus) Exceeded() bool {
+	return s.Size > s.Limit
+}
+
+// QuotaOption configures a quota set with SetQuota.
+type QuotaOption func(*quotaOptions)
+
+type quotaOptions struct {
+	revoked map[Account][]string
+}
+
+// WithRevokedGrants returns a QuotaOption that records write grants revoked
+// from the accounts by an earlier enforcement, as reported by
+// QuotaStatus.Revoked, so that they are restored once the database is back
+// under quota. Use it to reload the state persisted by a previous process or
+// by another controller enforcing the same quotas.
+func WithRevokedGrants(revoked map[Account][]string) QuotaOption {
+	return func(o *quotaOptions) {
+		o.revoked = revoked
+	}
+}
+
+func newQuotaOptions(opts []QuotaOption) quotaOptions {
+	var o quotaOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// validate checks that only write grants of valid accounts were revoked.
+func (o quotaOptions) validate() error {
+	for account, grantNames := range o.revoked {
+		err := validateAccount(account)
+		if err != nil {
+			return fmt.Errorf("error validating account: %w", err)
+		}
+		for _, grantName := range grantNames {
+			if !contains(quotaWriteGrants, strings.ToUpper(grantName)) {
+				return fmt.Errorf("%w: %s is not revoked by quotas", ErrInvalidGrant, grantName)
+			}
+		}
+	}
+	return nil
+}
+
+// quota is a registered size limit and the grants revoked to enforce it.
+type quota struct {
+	limit   int64
+	revoked map[Account][]string
+}
+
+// quotas are the registered quotas of a MySQLController. mu guards byName and
+// is never held during a query; enforce serializes the REVOKE and GRANT
+// statements run to enforce and remove quotas.
+type quotas struct {
+	mu      sync.Mutex
+	enforce sync.Mutex
+	byName  map[string]*quota
+}
+
+// SetQuota limits the size of the database to the given number of Bytes. The
+// limit is only enforced by EnforceQuota and EnforceQuotas.
+//
+// Quotas are kept in memory. To restore grants revoked before a restart or by
+// another controller, persist QuotaStatus.Revoked after enforcing and pass it
+// back with WithRevokedGrants.
+func (c *MySQLController) SetQuota(dbName string, limit int64, opts ...QuotaOption) error {
+	err := validateDBName(dbName)
+	if err != nil {
+		return err
+	}
+	if limit <= 0 {
+		return fmt.Errorf("quota limit must be positive")
+	}
+
+	o := newQuotaOptions(opts)
+	err = o.validate()
+	if err != nil {
+		return err
+	}
+
+	c.quotas.mu.Lock()
+	defer c.quotas.mu.Unlock()
+
+	if c.quotas.byName == nil {
+		c.quotas.byName = map[string]*quota{}
+	}
+	q, ok := c.quotas.byName[dbName]
+	if !ok {
+		q = &quota{revoked: map[Account][]string{}}
+		c.quotas.byName[dbName] = q
+	}
+	q.limit = limit
+	q.record(o.revoked, nil)
+	return nil
+}
+
+// RemoveQuota removes the quota of the database, restoring revoked grants.
+func (c *MySQLController) RemoveQuota(dbName string) error {
+	return c.RemoveQuotaContext(context.Background(), dbName)
+}
+
+// RemoveQuotaContext removes the quota of the database, restoring revoked grants.
+func (c *MySQLController) RemoveQuotaContext(ctx context.Context, dbName string) error {
+	c.quotas.enforce.Lock()
+	defer c.quotas.enforce.Unlock()
+
+	q, ok := c.quotaSnapshot(dbName)
+	if !ok {
+		return ErrNoQuota
+	}
+
+	restored, err := c.restoreQuotaGrants(ctx, dbName, q.revoked)
+
+	c.quotas.mu.Lock()
+	defer c.quotas.mu.Unlock()
+
+	c.quotas.byName[dbName].record(nil, restored)
+	if err != nil {
+		return err
+	}
+	delete(c.quotas.byName, dbName)
+	return nil
+}
+
+// Quotas returns the limit of every database with a quota.
+func (c *MySQLController) Quotas() map[string]int64 {
+	c.quotas.mu.Lock()
+	defer c.quotas.mu.Unlock()
+
+	limits := make(map[string]int64, len(c.quotas.byName))
+	for dbName, q := range c.quotas.byName {
+		limits[dbName] = q.limit
+	}
+	return limits
+}
+
+// CheckQuota returns the usage of the database without changing any grant.
+func (c *MySQLController) CheckQuota(dbName string) (QuotaStatus, error) {
+	return c.CheckQuotaContext(context.Background(), dbName)
+}
+
+// CheckQuotaContext returns the usage of the database without changing any grant.
+func (c *MySQLController) CheckQuotaContext(ctx context.Context, dbName string) (QuotaStatus, error) {
+	size, err := c.databaseSize(ctx, dbName)
+	if err != nil {
+		return QuotaStatus{}, err
+	}
+
+	q, ok := c.quotaSnapshot(dbName)
+	if !ok {
+		return QuotaStatus{}, ErrNoQuota
+	}
+	return q.status(dbName, size), nil
+}
+
+// EnforceQuota enforces the quota of the database.
+func (c *MySQLController) EnforceQuota(dbName string) (QuotaStatus, error) {
+	return c.EnforceQuotaContext(context.Background(), dbName)
+}
+
+// EnforceQuotaContext enforces the quota of the database. While it is over
+// quota, the write grants of every account with database-level grants on it
+// are revoked; once it is back under, they are granted again. Table-level and
+// pattern grants are left untouched.
+func (c *MySQLController) EnforceQuotaContext(ctx context.Context, dbName string) (QuotaStatus, error) {
+	size, err := c.databaseSize(ctx, dbName)
+	if err != nil {
+		return QuotaStatus{}, err
+	}
+	return c.enforceQuota(ctx, dbName, size)
+}
+
+// EnforceQuotas enforces the quota of every database with one.
+func (c *MySQLController) EnforceQuotas() ([]QuotaStatus, error) {
+	return c.EnforceQuotasContext(context.Background())
+}
+
+// EnforceQuotasContext enforces the quota of every database with one, sorted
+// by database name. The sizes are read with a single query.
+func (c *MySQLController) EnforceQuotasContext(ctx context.Context) ([]QuotaStatus, error) {
+	sizes, err := c.SizeAllContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := c.quotaNames()
+	var statuses []QuotaStatus
+	for _, dbName := range names {
+		size, ok := sizes[dbName]
+		if !ok {
+			// the database was deleted, nothing to enforce
+			continue
+		}
+		status, err := c.enforceQuota(ctx, dbName, size)
+		if errors.Is(err, ErrNoQuota) {
+			// the quota was removed in the meantime
+			continue
+		}
+		if err != nil {
+			return statuses, fmt.Errorf("error enforcing quota of %s: %w", dbName, err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// enforceQuota revokes or restores the write grants on the database given its
+// size. The statements run on a snapshot of the quota, without the quotas
+// locked, and their outcome is recorded once they are done.
+func (c *MySQLController) enforceQuota(ctx context.Context, dbName string, size int64) (QuotaStatus, error) {
+	c.quotas.enforce.Lock()
+	defer c.quotas.enforce.Unlock()
+
+	q, ok := c.quotaSnapshot(dbName)
+	if !ok {
+		return QuotaStatus{}, ErrNoQuota
+	}
+
+	if size <= q.limit {
+		restored, err := c.restoreQuotaGrants(ctx, dbName, q.revoked)
+		return c.recordQuotaGrants(dbName, size, nil, restored), err
+	}
+
+	access, err := c.DatabaseUsersContext(ctx, dbName)
+	if err != nil {
+		return q.status(dbName, size), err
+	}
+
+	// accounts granted write access since the last check are revoked too
+	revoked := map[Account][]string{}
+	for _, a := range access {
+		var write []string
+		for _, grantName := range a.Privileges {
+			if contains(quotaWriteGrants, grantName) {
+				write = append(write, grantName)
+			}
+		}
+		if len(write) == 0 {
+			continue
+		}
+
+		err = c.revokePrivileges(ctx, write, dbName, a.Account)
+		if err != nil {
+			return c.recordQuotaGrants(dbName, size, revoked, nil), err
+		}
+		revoked[a.Account] = write
+	}
+	return c.recordQuotaGrants(dbName, size, revoked, nil), nil
+}
+
+// restoreQuotaGrants grants the revoked write grants on the database again
+// and returns the grants that no longer need restoring. Accounts deleted in
+// the meantime are forgotten.
+func (c *MySQLController) restoreQuotaGrants(ctx context.Context, dbName string, revoked map[Account][]string) (map[Account][]string, error) {
+	restored := map[Account][]string{}
+	for account, grantNames := range revoked {
+		ok, err := c.AccountExistsContext(ctx, account)
+		if err != nil {
+			return restored, err
+		}
+		if ok {
+			err = c.grantPrivileges(ctx, grantNames, dbName, account)
+			if err != nil {
+				return restored, err
+			}
+		}
+		restored[account] = grantNames
+	}
+	return restored, nil
+}
+
+// quotaSnapshot returns a copy of the quota of the database.
+func (c *MySQLController) quotaSnapshot(dbName string) (quota, bool) {
+	c.quotas.mu.Lock()
+	defer c.quotas.mu.Unlock()
+
+	q, ok := c.quotas.byName[dbName]
+	if !ok {
+		return quota{}, false
+	}
+	return quota{limit: q.limit, revoked: copyRevoked(q.revoked)}, true
+}
+
+// quotaNames returns the sorted names of the databases with a quota.
+func (c *MySQLController) quotaNames() []string {
+	c.quotas.mu.Lock()
+	defer c.quotas.mu.Unlock()
+
+	names := make([]string, 0, len(c.quotas.byName))
+	for dbName := range c.quotas.byName {
+		names = append(names, dbName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recordQuotaGrants records the grants revoked and restored on the database
+// and returns its QuotaStatus.
+func (c *MySQLController) recordQuotaGrants(dbName string, size int64, revoked, restored map[Account][]string) QuotaStatus {
+	c.quotas.mu.Lock()
+	defer c.quotas.mu.Unlock()
+
+	q := c.quotas.byName[dbName]
+	q.record(revoked, restored)
+	return q.status(dbName, size)
+}
+
+// record adds the revoked grants and removes the restored ones. It must be
+// called with the quotas locked.
+func (q *quota) record(revoked, restored map[Account][]string) {
+	for account, grantNames := range revoked {
+		upper := make([]string, len(grantNames))
+		for i, grantName := range grantNames {
+			upper[i] = strings.ToUpper(grantName)
+		}
+		q.revoked[account] = uniqueGrants(append(q.revoked[account], upper...))
+	}
+	for account, grantNames := range restored {
+		var remaining []string
+		for _, grantName := range q.revoked[account] {
+			if !contains(grantNames, grantName) {
+				remaining = append(remaining, grantName)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(q.revoked, account)
+		} else {
+			q.revoked[account] = remaining
+		}
+	}
+}
+
+// status returns the QuotaStatus of the database given its size.
+func (q *quota) status(dbName string, size int64) QuotaStatus {
+	return QuotaStatus{Database: dbName, Limit: q.limit, Size: size, Revoked: copyRevoked(q.revoked)}
+}
+
+// copyRevoked returns a deep copy of the revoked grants.
+func copyRevoked(revoked map[Account][]string) map[Account][]string {
+	c := make(map[Account][]string, len(revoked))
+	for account, grants := range revoked {
+		c[account] = append([]string(nil), grants...)
+	}
+	return c
+}
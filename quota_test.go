@@ -0,0 +1,191 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_EnforceQuota(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.GrantAll(testDB, testUser)
+	assert.NoError(t, err)
+
+	_, err = c.db.Exec("CREATE TABLE `test-db`.test (id INT, name VARCHAR(255))")
+	assert.NoError(t, err)
+
+	_, err = c.EnforceQuota(testDB)
+	assert.Equal(t, ErrNoQuota, err)
+
+	err = c.SetQuota(testDB, 1024)
+	assert.NoError(t, err)
+	defer c.RemoveQuota(testDB)
+
+	status, err := c.CheckQuota(testDB)
+	assert.NoError(t, err)
+	assert.True(t, status.Exceeded())
+	assert.Empty(t, status.Revoked)
+
+	status, err = c.EnforceQuota(testDB)
+	assert.NoError(t, err)
+	assert.True(t, status.Exceeded())
+	assert.Equal(t, map[Account][]string{NewAccount(testUser): quotaWriteGrants}, status.Revoked)
+
+	for _, g := range quotaWriteGrants {
+		b, err := c.GrantExists(g, testDB, testUser)
+		assert.NoError(t, err)
+		assert.False(t, b, g)
+	}
+	for _, g := range []string{"SELECT", "DELETE", "DROP"} {
+		b, err := c.GrantExists(g, testDB, testUser)
+		assert.NoError(t, err)
+		assert.True(t, b, g)
+	}
+
+	// enforcing again changes nothing
+	status, err = c.EnforceQuota(testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, map[Account][]string{NewAccount(testUser): quotaWriteGrants}, status.Revoked)
+
+	err = c.SetQuota(testDB, 1024*1024)
+	assert.NoError(t, err)
+
+	statuses, err := c.EnforceQuotas()
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.False(t, statuses[0].Exceeded())
+		assert.Empty(t, statuses[0].Revoked)
+	}
+
+	p, err := c.DetectProfile(testDB, testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, ProfileOwner.Name, p.Name)
+}
+
+func TestMySQLController_RemoveQuota(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.Grant("insert", testDB, testUser)
+	assert.NoError(t, err)
+	_, err = c.db.Exec("CREATE TABLE `test-db`.test (id INT, name VARCHAR(255))")
+	assert.NoError(t, err)
+
+	err = c.SetQuota(testDB, 1)
+	assert.NoError(t, err)
+
+	_, err = c.EnforceQuota(testDB)
+	assert.NoError(t, err)
+
+	b, err := c.GrantExists("insert", testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.RemoveQuota(testDB)
+	assert.NoError(t, err)
+
+	b, err = c.GrantExists("insert", testDB, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+}
+
+func TestMySQLController_SetQuota(t *testing.T) {
+	c := &MySQLController{}
+
+	err := c.SetQuota(testDB, 100)
+	assert.NoError(t, err)
+
+	err = c.SetQuota(testDB, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{testDB: 200}, c.Quotas())
+
+	assert.Error(t, c.SetQuota(testDB, 0))
+	assert.Error(t, c.SetQuota("", 100))
+	assert.Error(t, c.SetQuota("mysql", 100))
+
+	err = c.RemoveQuota(testDB)
+	assert.NoError(t, err)
+	assert.Empty(t, c.Quotas())
+
+	err = c.RemoveQuota(testDB)
+	assert.Equal(t, ErrNoQuota, err)
+
+	account := NewAccount(testUser)
+	err = c.SetQuota(testDB, 100, WithRevokedGrants(map[Account][]string{account: {"update", "INSERT"}}))
+	assert.NoError(t, err)
+	q, ok := c.quotaSnapshot(testDB)
+	assert.True(t, ok)
+	assert.Equal(t, map[Account][]string{account: {"INSERT", "UPDATE"}}, q.revoked)
+
+	assert.Error(t, c.SetQuota(testDB, 100, WithRevokedGrants(map[Account][]string{account: {"DELETE"}})))
+	assert.Error(t, c.SetQuota(testDB, 100, WithRevokedGrants(map[Account][]string{{User: testUser}: {"INSERT"}})))
+}
+
+func Test_quota_record(t *testing.T) {
+	account := NewAccount(testUser)
+	q := &quota{limit: 100, revoked: map[Account][]string{}}
+
+	q.record(map[Account][]string{account: {"UPDATE", "INSERT"}}, nil)
+	q.record(map[Account][]string{account: {"INSERT", "ALTER"}}, nil)
+	assert.Equal(t, map[Account][]string{account: {"ALTER", "INSERT", "UPDATE"}}, q.revoked)
+
+	// grants restored by one run are kept if another revoked them since
+	q.record(nil, map[Account][]string{account: {"INSERT", "UPDATE"}})
+	assert.Equal(t, map[Account][]string{account: {"ALTER"}}, q.revoked)
+
+	q.record(nil, map[Account][]string{account: {"ALTER"}})
+	assert.Empty(t, q.revoked)
+}
+
+func TestMySQLController_QuotaReload(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.GrantMany(testDB, testUser, "select", "insert", "update")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("CREATE TABLE `test-db`.test (id INT, name VARCHAR(255))")
+	assert.NoError(t, err)
+
+	err = c.SetQuota(testDB, 1)
+	assert.NoError(t, err)
+	status, err := c.EnforceQuota(testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, map[Account][]string{NewAccount(testUser): {"INSERT", "UPDATE"}}, status.Revoked)
+
+	// a new controller, e.g. after a restart, restores what the first revoked
+	restarted := createTestController()
+	err = restarted.SetQuota(testDB, 1024*1024, WithRevokedGrants(status.Revoked))
+	assert.NoError(t, err)
+
+	status, err = restarted.EnforceQuota(testDB)
+	assert.NoError(t, err)
+	assert.Empty(t, status.Revoked)
+
+	for _, g := range []string{"SELECT", "INSERT", "UPDATE"} {
+		b, err := c.GrantExists(g, testDB, testUser)
+		assert.NoError(t, err)
+		assert.True(t, b, g)
+	}
+}
+
+func Test_quotaWriteGrants(t *testing.T) {
+	for _, g := range quotaWriteGrants {
+		assert.NoError(t, validateGrant(g))
+	}
+	assert.Equal(t, quotaWriteGrants, uniqueGrants(append([]string(nil), quotaWriteGrants...)))
+	assert.NotContains(t, quotaWriteGrants, "DELETE")
+	assert.NotContains(t, quotaWriteGrants, "DROP")
+
+	assert.Equal(t, []string{"INSERT", "UPDATE"}, uniqueGrants([]string{"UPDATE", "INSERT", "UPDATE"}))
+}
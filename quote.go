@@ -0,0 +1,81 @@
+package mysqlctl
+
+import (
+	"regexp"
+	"strings"
+)
+
+// quoteIdentifier quotes a schema object name (database, table, column) with
+// backticks, doubling any backticks it contains, so that it is always parsed
+// as a single identifier.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// quoteString quotes a value as a single-quoted string literal.
+//
+// Single quotes are doubled. Backslashes are escaped, unless the session runs
+// with the NO_BACKSLASH_ESCAPES SQL mode, where they are taken literally; no
+// single quoting holds a backslash in both modes, so callers must read the
+// mode of the connection the statement runs on (see execQuoted). Prefer query
+// placeholders wherever MySQL accepts them; this is meant for statements such
+// as CREATE USER that do not.
+func quoteString(s string, noBackslashEscapes bool) string {
+	if noBackslashEscapes {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return "'" + stringEscaper.Replace(s) + "'"
+}
+
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
+// quoteAccount returns the quoted `user`@`host` name of the given account, as
+// used in CREATE USER, GRANT and friends. The parts are quoted as identifiers,
+// which, unlike string literals, mean the same in every SQL mode.
+func quoteAccount(account Account) string {
+	return quoteIdentifier(account.User) + "@" + quoteIdentifier(account.Host)
+}
+
+// EscapeDatabasePattern escapes the _ and % wildcards (and the \ escape
+// character) of a database name, so that a database-level grant on it only
+// applies to that database and not to every name the pattern would match.
+func EscapeDatabasePattern(dbName string) string {
+	return patternEscaper.Replace(dbName)
+}
+
+var patternEscaper = strings.NewReplacer(`\`, `\\`, `_`, `\_`, `%`, `\%`)
+
+// quoteDatabase quotes a literal database name for the ON clause of a
+// database-level GRANT or REVOKE, where MySQL treats _ and % as wildcards.
+func quoteDatabase(dbName string) string {
+	return quoteIdentifier(EscapeDatabasePattern(dbName))
+}
+
+// matchDatabasePattern reports whether the database-level grant pattern
+// matches the database name the way MySQL does: _ matches one character, %
+// any number of them and \ escapes the next character.
+func matchDatabasePattern(pattern, dbName string) bool {
+	var expr strings.Builder
+	expr.WriteString(`^(?s:`)
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '_':
+			expr.WriteString(`.`)
+		case r == '%':
+			expr.WriteString(`.*`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		expr.WriteString(regexp.QuoteMeta(`\`))
+	}
+	expr.WriteString(`)$`)
+	return regexp.MustCompile(expr.String()).MatchString(dbName)
+}
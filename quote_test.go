@@ -0,0 +1,179 @@
+package mysqlctl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// quoteSeeds are inputs that break naive string concatenation.
+var quoteSeeds = []string{
+	"",
+	"test-db",
+	"a`b",
+	"``",
+	"a'b",
+	"''",
+	`\`,
+	`\'`,
+	`'\`,
+	"x`; DROP DATABASE mysql; --",
+	"x' OR '1'='1",
+	`x\' OR 1=1 -- `,
+	"\x00\n\r\x1a",
+	"пароль",
+}
+
+// scanIdentifier parses a backtick-quoted identifier the way the MySQL lexer
+// does and returns its value and the number of bytes consumed.
+func scanIdentifier(q string) (string, int) {
+	if !strings.HasPrefix(q, "`") {
+		return "", 0
+	}
+	var b strings.Builder
+	for i := 1; i < len(q); i++ {
+		if q[i] != '`' {
+			b.WriteByte(q[i])
+			continue
+		}
+		if i+1 < len(q) && q[i+1] == '`' {
+			b.WriteByte('`')
+			i++
+			continue
+		}
+		return b.String(), i + 1
+	}
+	return "", 0
+}
+
+// scanString parses a single-quoted string literal the way the MySQL lexer
+// does and returns its value and the number of bytes consumed.
+// backslashEscapes is false when NO_BACKSLASH_ESCAPES is enabled.
+func scanString(q string, backslashEscapes bool) (string, int) {
+	if !strings.HasPrefix(q, "'") {
+		return "", 0
+	}
+	var b strings.Builder
+	for i := 1; i < len(q); i++ {
+		switch {
+		case backslashEscapes && q[i] == '\\' && i+1 < len(q):
+			b.WriteByte(q[i+1])
+			i++
+		case q[i] == '\'' && i+1 < len(q) && q[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case q[i] == '\'':
+			return b.String(), i + 1
+		default:
+			b.WriteByte(q[i])
+		}
+	}
+	return "", 0
+}
+
+func Test_quoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`test-db`", quoteIdentifier("test-db"))
+	assert.Equal(t, "`a``b`", quoteIdentifier("a`b"))
+	assert.Equal(t, "``", quoteIdentifier(""))
+}
+
+func Test_quoteString(t *testing.T) {
+	assert.Equal(t, "'test-password'", quoteString("test-password", false))
+	assert.Equal(t, "'a''b'", quoteString("a'b", false))
+	assert.Equal(t, `'a\\b'`, quoteString(`a\b`, false))
+	assert.Equal(t, `'a\b'`, quoteString(`a\b`, true))
+	assert.Equal(t, `'a\''b'`, quoteString(`a\'b`, true))
+}
+
+func Test_quoteAccount(t *testing.T) {
+	assert.Equal(t, "`test-user`@`%`", quoteAccount(NewAccount("test-user")))
+	assert.Equal(t, "`test-user`@`10.0.%`", quoteAccount(Account{User: "test-user", Host: "10.0.%"}))
+	assert.Equal(t, "`x'@'%`@`%`", quoteAccount(NewAccount("x'@'%")))
+	assert.Equal(t, "`x``@``%`@`%`", quoteAccount(NewAccount("x`@`%")))
+	assert.Equal(t, "`a\\b`@`%`", quoteAccount(NewAccount(`a\b`)))
+}
+
+func TestEscapeDatabasePattern(t *testing.T) {
+	assert.Equal(t, "test-db", EscapeDatabasePattern("test-db"))
+	assert.Equal(t, `app\_1`, EscapeDatabasePattern("app_1"))
+	assert.Equal(t, `100\%`, EscapeDatabasePattern("100%"))
+	assert.Equal(t, `a\\b`, EscapeDatabasePattern(`a\b`))
+	assert.Equal(t, "`app\\_1`", quoteDatabase("app_1"))
+}
+
+func Test_matchDatabasePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		dbName  string
+		want    bool
+	}{
+		{"app_1", "app_1", true},
+		{"app_1", "appX1", true},
+		{`app\_1`, "app_1", true},
+		{`app\_1`, "appX1", false},
+		{"tenant%", "tenant", true},
+		{"tenant%", "tenant-42", true},
+		{`tenant\%`, "tenant-42", false},
+		{"%", "mysql", true},
+		{"m_sql", "mysql", true},
+		{"my%", "sys", false},
+		{"a.b", "axb", false},
+		{`a\`, `a\`, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchDatabasePattern(tt.pattern, tt.dbName), "%q LIKE %q", tt.dbName, tt.pattern)
+	}
+}
+
+func FuzzQuoteIdentifier(f *testing.F) {
+	for _, s := range quoteSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		q := quoteIdentifier(s)
+		v, n := scanIdentifier(q)
+		if n != len(q) {
+			t.Fatalf("identifier %q escapes its quotes in %q", s, q)
+		}
+		if v != s {
+			t.Fatalf("identifier %q parsed as %q", s, v)
+		}
+	})
+}
+
+func FuzzQuoteString(f *testing.F) {
+	for _, s := range quoteSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, noBackslashEscapes := range []bool{false, true} {
+			q := quoteString(s, noBackslashEscapes)
+			v, n := scanString(q, !noBackslashEscapes)
+			if n != len(q) {
+				t.Fatalf("string %q escapes its quotes in %q (NO_BACKSLASH_ESCAPES: %v)", s, q, noBackslashEscapes)
+			}
+			if v != s {
+				t.Fatalf("string %q parsed as %q (NO_BACKSLASH_ESCAPES: %v)", s, v, noBackslashEscapes)
+			}
+		}
+	})
+}
+
+func FuzzEscapeDatabasePattern(f *testing.F) {
+	for _, s := range append(quoteSeeds, "app_1", "100%", `a\_%`) {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		p := EscapeDatabasePattern(s)
+		if !matchDatabasePattern(p, s) {
+			t.Fatalf("escaped pattern %q does not match %q", p, s)
+		}
+		if matchDatabasePattern(p, s+"x") {
+			t.Fatalf("escaped pattern %q matches %q", p, s+"x")
+		}
+		if r := strings.NewReplacer("_", "x", "%", "x").Replace(s); r != s && matchDatabasePattern(p, r) {
+			t.Fatalf("escaped pattern %q matches %q", p, r)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package mysqlctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnsafeRename = fmt.Errorf("database cannot be renamed safely")
+)
+
+// RenameDatabase renames a database.
+func (c *MySQLController) RenameDatabase(oldName, newName string) error {
+	return c.RenameDatabaseContext(context.Background(), oldName, newName)
+}
+
+// RenameDatabaseContext renames a database, which MySQL cannot do itself. It
+// creates the new database with the same defaults, moves every table to it
+// with a single RENAME TABLE, moves the database-level grants of every
+// account listed by DatabaseUsers and drops the old database once it is
+// empty. If any step fails, the previous ones are rolled back.
+//
+// Databases with views, triggers, stored routines or events are refused with
+// ErrUnsafeRename, as those keep referring to the old name. Table-level and
+// column-level grants are not moved.
+func (c *MySQLController) RenameDatabaseContext(ctx context.Context, oldName, newName string) (err error) {
+	err = validateDBName(oldName)
+	if err != nil {
+		return err
+	}
+	err = validateDBName(newName)
+	if err != nil {
+		return err
+	}
+	if oldName == newName {
+		return fmt.Errorf("database names are the same")
+	}
+
+	info, err := c.DatabaseInfoContext(ctx, oldName)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkRenameSafe(ctx, oldName)
+	if err != nil {
+		return err
+	}
+
+	// undo are the rollback steps of what was done so far, run in reverse
+	// order. They use their own context, as ctx may be the reason to roll back.
+	var undo []func(context.Context) error
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(undo) - 1; i >= 0; i-- {
+			rollbackErr := undo[i](context.Background())
+			if rollbackErr != nil {
+				err = fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+				return
+			}
+		}
+	}()
+
+	opts := []DatabaseOption{WithCharset(info.CharacterSet), WithCollation(info.Collation)}
+	if info.Encrypted {
+		opts = append(opts, WithEncryption(true))
+	}
+	err = c.CreateDatabaseContext(ctx, newName, opts...)
+	if err != nil {
+		return err
+	}
+	undo = append(undo, func(ctx context.Context) error {
+		return c.DeleteDatabaseContext(ctx, newName)
+	})
+
+	tables, err := c.TablesContext(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	if len(tables) > 0 {
+		err = c.renameTables(ctx, tables, oldName, newName)
+		if err != nil {
+			return err
+		}
+		moved := tables
+		undo = append(undo, func(ctx context.Context) error {
+			return c.renameTables(ctx, moved, newName, oldName)
+		})
+	}
+
+	access, err := c.DatabaseUsersContext(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	for _, a := range access {
+		account := a.Account
+		var privileges []string
+		grantOption := false
+		for _, privilege := range a.Privileges {
+			if privilege == "GRANT OPTION" {
+				grantOption = true
+			} else {
+				privileges = append(privileges, privilege)
+			}
+		}
+		if len(privileges) == 0 && !grantOption {
+			// only table-level or column-level grants
+			continue
+		}
+
+		// registered first, as the move can fail after granting on newName
+		undo = append(undo, func(ctx context.Context) error {
+			return c.moveDatabaseGrants(ctx, account, privileges, grantOption, newName, oldName)
+		})
+		err = c.moveDatabaseGrants(ctx, account, privileges, grantOption, oldName, newName)
+		if err != nil {
+			return err
+		}
+	}
+
+	// DROP DATABASE would also drop tables created since they were listed
+	created, err := c.TablesContext(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	if len(created) > 0 {
+		return fmt.Errorf("%w: tables were created in %s during the rename", ErrUnsafeRename, oldName)
+	}
+
+	return c.DeleteDatabaseContext(ctx, oldName)
+}
+
+// checkRenameSafe returns ErrUnsafeRename if the database has objects that
+// refer to it by name.
+func (c *MySQLController) checkRenameSafe(ctx context.Context, dbName string) error {
+	q := "SELECT" +
+		" (SELECT COUNT(*) FROM information_schema.views WHERE table_schema = ?)," +
+		" (SELECT COUNT(*) FROM information_schema.triggers WHERE trigger_schema = ?)," +
+		" (SELECT COUNT(*) FROM information_schema.routines WHERE routine_schema = ?)," +
+		" (SELECT COUNT(*) FROM information_schema.events WHERE event_schema = ?)"
+	var views, triggers, routines, events int
+	err := c.db.QueryRowContext(ctx, q, dbName, dbName, dbName, dbName).Scan(&views, &triggers, &routines, &events)
+	if err != nil {
+		return classifyError(err, nil)
+	}
+
+	var objects []string
+	for _, o := range []struct {
+		count int
+		name  string
+	}{{views, "views"}, {triggers, "triggers"}, {routines, "routines"}, {events, "events"}} {
+		if o.count > 0 {
+			objects = append(objects, fmt.Sprintf("%d %s", o.count, o.name))
+		}
+	}
+	if len(objects) > 0 {
+		return fmt.Errorf("%w: %s has %s", ErrUnsafeRename, dbName, strings.Join(objects, ", "))
+	}
+	return nil
+}
+
+// renameTables moves the tables from one database to another in a single,
+// atomic RENAME TABLE statement. It does nothing if there are no tables.
+func (c *MySQLController) renameTables(ctx context.Context, tables []string, from, to string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	renames := make([]string, len(tables))
+	for i, table := range tables {
+		renames[i] = quoteIdentifier(from) + "." + quoteIdentifier(table) + " TO " + quoteIdentifier(to) + "." + quoteIdentifier(table)
+	}
+
+	_, err := c.db.ExecContext(ctx, "RENAME TABLE "+strings.Join(renames, ", "))
+	if err != nil {
+		return fmt.Errorf("error renaming tables: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// moveDatabaseGrants grants the privileges on one database to the account
+// and revokes every privilege it has on another. Missing grants on the latter
+// are ignored, so that it also undoes a move that failed halfway.
+func (c *MySQLController) moveDatabaseGrants(ctx context.Context, account Account, privileges []string, grantOption bool, from, to string) error {
+	var opts []GrantOption
+	if grantOption {
+		opts = append(opts, WithGrantOption())
+	}
+
+	var err error
+	if len(privileges) > 0 {
+		err = c.grantPrivileges(ctx, privileges, to, account, opts...)
+	} else {
+		_, err = c.db.ExecContext(ctx, "GRANT USAGE ON "+quoteDatabase(to)+".* TO "+quoteAccount(account)+" WITH GRANT OPTION")
+		if err != nil {
+			err = fmt.Errorf("error granting privileges: %w", classifyError(err, nil))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// ALL PRIVILEGES does not include GRANT OPTION
+	for _, privileges := range []string{"ALL PRIVILEGES", "GRANT OPTION"} {
+		err = c.revokeOnDatabase(ctx, privileges, from, account)
+		if err != nil && !errors.Is(err, ErrGrantDoesNotExist) {
+			return err
+		}
+	}
+	return nil
+}
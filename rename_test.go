@@ -0,0 +1,168 @@
+package mysqlctl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRenamedDB = "test-db-renamed"
+
+func TestMySQLController_RenameDatabase(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB, WithCharset("latin1"), WithCollation("latin1_bin"))
+	defer c.DeleteDatabase(testDB)
+	defer c.DeleteDatabase(testRenamedDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	_, err := c.db.Exec("CREATE TABLE `test-db`.users (id INT, name VARCHAR(255))")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("INSERT INTO `test-db`.users VALUES (1, 'alice')")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("CREATE TABLE `test-db`.events (id INT)")
+	assert.NoError(t, err)
+
+	err = c.GrantMany(testDB, testUser, "select", "insert")
+	assert.NoError(t, err)
+	err = c.Grant("update", testDB, testUser, WithGrantOption())
+	assert.NoError(t, err)
+
+	err = c.RenameDatabase(testDB, testRenamedDB)
+	assert.NoError(t, err)
+
+	b, err := c.DatabaseExists(testDB)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	info, err := c.DatabaseInfo(testRenamedDB)
+	assert.NoError(t, err)
+	assert.Equal(t, "latin1", info.CharacterSet)
+	assert.Equal(t, "latin1_bin", info.Collation)
+
+	tables, err := c.Tables(testRenamedDB)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"users", "events"}, tables)
+
+	var name string
+	err = c.db.QueryRow("SELECT name FROM `test-db-renamed`.users WHERE id = 1").Scan(&name)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", name)
+
+	p, err := c.ListGrants(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabasePrivileges{{Database: testRenamedDB, Privileges: []string{"GRANT OPTION", "INSERT", "SELECT", "UPDATE"}}}, p.Databases)
+
+	err = c.RenameDatabase(testRenamedDB, testRenamedDB)
+	assert.Error(t, err)
+
+	err = c.RenameDatabase(testDB, testRenamedDB)
+	assert.Equal(t, ErrDBDoesNotExist, err)
+
+	c.CreateDatabase(testDB)
+	err = c.RenameDatabase(testRenamedDB, testDB)
+	assert.ErrorIs(t, err, ErrDBExists)
+
+	// nothing was changed
+	tables, err = c.Tables(testRenamedDB)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+
+	b, err = c.GrantExists("select", testRenamedDB, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+}
+
+func TestMySQLController_RenameDatabaseUnsafe(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+
+	_, err := c.db.Exec("CREATE TABLE `test-db`.users (id INT, name VARCHAR(255))")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("CREATE VIEW `test-db`.names AS SELECT name FROM `test-db`.users")
+	assert.NoError(t, err)
+
+	err = c.RenameDatabase(testDB, testRenamedDB)
+	assert.ErrorIs(t, err, ErrUnsafeRename)
+
+	b, err := c.DatabaseExists(testRenamedDB)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	tables, err := c.Tables(testDB)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+}
+
+func TestMySQLController_moveDatabaseGrants(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	account := NewAccount(testUser)
+	err := c.moveDatabaseGrants(context.Background(), account, []string{"SELECT"}, true, testDB, testRenamedDB)
+	assert.NoError(t, err)
+
+	p, err := c.ListGrants(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabasePrivileges{{Database: testRenamedDB, Privileges: []string{"GRANT OPTION", "SELECT"}}}, p.Databases)
+
+	// undoing a move that failed halfway leaves no grant on the new name
+	err = c.moveDatabaseGrants(context.Background(), account, []string{"SELECT"}, true, testRenamedDB, testDB)
+	assert.NoError(t, err)
+	err = c.moveDatabaseGrants(context.Background(), account, []string{"SELECT"}, true, testRenamedDB, testDB)
+	assert.NoError(t, err)
+
+	p, err = c.ListGrants(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []DatabasePrivileges{{Database: testDB, Privileges: []string{"GRANT OPTION", "SELECT"}}}, p.Databases)
+}
+
+func TestMySQLController_RenameDatabaseRollback(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	defer c.DeleteDatabase(testRenamedDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	_, err := c.db.Exec("CREATE TABLE `test-db`.users (id INT, name VARCHAR(255))")
+	assert.NoError(t, err)
+
+	// the account can move the table both ways but not drop the old database,
+	// so the rename fails after the table was moved
+	_, err = c.db.Exec("GRANT ALL PRIVILEGES ON `test-db-renamed`.* TO 'test-user'@'%'")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("GRANT ALTER, CREATE, DROP, INSERT ON `test-db`.users TO 'test-user'@'%'")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("GRANT SELECT ON mysql.* TO 'test-user'@'%'")
+	assert.NoError(t, err)
+
+	db, err := openMySQLWithDB(testUser, testPassword, "")
+	assert.NoError(t, err)
+	defer db.Close()
+	limited := &MySQLController{db: db}
+
+	err = limited.RenameDatabase(testDB, testRenamedDB)
+	assert.ErrorIs(t, err, ErrAccessDenied)
+	assert.NotContains(t, err.Error(), "rollback failed")
+
+	tables, err := c.Tables(testDB)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users"}, tables)
+
+	b, err := c.DatabaseExists(testRenamedDB)
+	assert.NoError(t, err)
+	assert.False(t, b)
+}
+
+func TestMySQLController_renameTables(t *testing.T) {
+	c := createTestController()
+
+	err := c.renameTables(context.Background(), nil, testDB, testRenamedDB)
+	assert.NoError(t, err)
+}
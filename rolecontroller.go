@@ -0,0 +1,362 @@
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RoleController manages MySQL 8 roles. Roles are referred to by name and
+// live on the DefaultHost.
+type RoleController interface {
+	CreateRole(role string) error
+	DropRole(role string) error
+	ListRoles() ([]string, error)
+	RoleExists(role string) (bool, error)
+	GrantToRole(grantName, dbName, role string) error
+	RevokeFromRole(grantName, dbName, role string) error
+	GrantRole(role, username string) error
+	RevokeRole(role, username string) error
+	SetDefaultRoles(username string, roles ...string) error
+	UserRoles(username string) ([]UserRole, error)
+
+	GrantRoleToAccount(role string, account Account) error
+	RevokeRoleFromAccount(role string, account Account) error
+	SetDefaultRolesForAccount(account Account, roles ...string) error
+	AccountRoles(account Account) ([]UserRole, error)
+}
+
+// RoleControllerContext is the context-aware counterpart of RoleController.
+type RoleControllerContext interface {
+	CreateRoleContext(ctx context.Context, role string) error
+	DropRoleContext(ctx context.Context, role string) error
+	ListRolesContext(ctx context.Context) ([]string, error)
+	RoleExistsContext(ctx context.Context, role string) (bool, error)
+	GrantToRoleContext(ctx context.Context, grantName, dbName, role string) error
+	RevokeFromRoleContext(ctx context.Context, grantName, dbName, role string) error
+	GrantRoleContext(ctx context.Context, role, username string) error
+	RevokeRoleContext(ctx context.Context, role, username string) error
+	SetDefaultRolesContext(ctx context.Context, username string, roles ...string) error
+	UserRolesContext(ctx context.Context, username string) ([]UserRole, error)
+
+	GrantRoleToAccountContext(ctx context.Context, role string, account Account) error
+	RevokeRoleFromAccountContext(ctx context.Context, role string, account Account) error
+	SetDefaultRolesForAccountContext(ctx context.Context, account Account, roles ...string) error
+	AccountRolesContext(ctx context.Context, account Account) ([]UserRole, error)
+}
+
+var (
+	_ RoleController        = &MySQLController{}
+	_ RoleControllerContext = &MySQLController{}
+)
+
+var (
+	ErrRoleExists       = fmt.Errorf("role exists")
+	ErrRoleDoesNotExist = fmt.Errorf("role does not exist")
+)
+
+// UserRole is a role granted to a user.
+type UserRole struct {
+	Name string
+	// Default is true if the role is activated when the user connects.
+	Default bool
+}
+
+// CreateRole creates a role.
+func (c *MySQLController) CreateRole(role string) error {
+	return c.CreateRoleContext(context.Background(), role)
+}
+
+// CreateRoleContext creates a role.
+func (c *MySQLController) CreateRoleContext(ctx context.Context, role string) error {
+	err := validateRoleName(role)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, "CREATE ROLE "+quoteAccount(NewAccount(role)))
+	return classifyError(err, map[uint16]error{erCannotUser: ErrRoleExists})
+}
+
+// DropRole drops a role, revoking it from every user.
+func (c *MySQLController) DropRole(role string) error {
+	return c.DropRoleContext(context.Background(), role)
+}
+
+// DropRoleContext drops a role, revoking it from every user. Accounts that
+// RoleExistsContext does not take for a role are refused with
+// ErrRoleDoesNotExist, as DROP ROLE would drop a user of the same name.
+func (c *MySQLController) DropRoleContext(ctx context.Context, role string) error {
+	err := c.checkRole(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, "DROP ROLE "+quoteAccount(NewAccount(role)))
+	return classifyError(err, map[uint16]error{erCannotUser: ErrRoleDoesNotExist})
+}
+
+// ListRoles returns the names of all roles.
+func (c *MySQLController) ListRoles() ([]string, error) {
+	return c.ListRolesContext(context.Background())
+}
+
+// ListRolesContext returns the names of all roles. MySQL does not record
+// which accounts are roles, so these are the accounts granted to another one
+// (in mysql.role_edges) and the locked, expired accounts without credentials
+// that CREATE ROLE creates. An ungranted role that was unlocked or given a
+// password is therefore missed, while an ordinary account in the same state
+// as a new role, e.g. a locked auth_socket account with an expired password,
+// is listed.
+func (c *MySQLController) ListRolesContext(ctx context.Context) ([]string, error) {
+	q := "SELECT user FROM mysql.user WHERE host = ? AND (" +
+		"(account_locked = 'Y' AND password_expired = 'Y' AND authentication_string = '')" +
+		" OR user IN (SELECT FROM_USER FROM mysql.role_edges WHERE FROM_HOST = ?)) ORDER BY user"
+	roles, err := c.queryStrings(ctx, q, DefaultHost, DefaultHost)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []string
+	for _, role := range roles {
+		if !contains(baseUsers, role) {
+			filtered = append(filtered, role)
+		}
+	}
+	return filtered, nil
+}
+
+// RoleExists returns true if the role exists.
+func (c *MySQLController) RoleExists(role string) (bool, error) {
+	return c.RoleExistsContext(context.Background(), role)
+}
+
+// RoleExistsContext returns true if the role exists, as listed by ListRoles.
+func (c *MySQLController) RoleExistsContext(ctx context.Context, role string) (bool, error) {
+	err := validateRoleName(role)
+	if err != nil {
+		return false, err
+	}
+
+	roles, err := c.ListRolesContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	return contains(roles, role), nil
+}
+
+// GrantToRole grants the given grant on the database to the role.
+func (c *MySQLController) GrantToRole(grantName, dbName, role string) error {
+	return c.GrantToRoleContext(context.Background(), grantName, dbName, role)
+}
+
+// GrantToRoleContext grants the given grant on the database to the role, or
+// returns ErrRoleDoesNotExist if it is not one.
+func (c *MySQLController) GrantToRoleContext(ctx context.Context, grantName, dbName, role string) error {
+	err := c.checkRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	return c.GrantToAccountContext(ctx, grantName, dbName, NewAccount(role))
+}
+
+// RevokeFromRole revokes the given grant on the database from the role.
+func (c *MySQLController) RevokeFromRole(grantName, dbName, role string) error {
+	return c.RevokeFromRoleContext(context.Background(), grantName, dbName, role)
+}
+
+// RevokeFromRoleContext revokes the given grant on the database from the
+// role, or returns ErrRoleDoesNotExist if it is not one.
+func (c *MySQLController) RevokeFromRoleContext(ctx context.Context, grantName, dbName, role string) error {
+	err := c.checkRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	return c.RevokeFromAccountContext(ctx, grantName, dbName, NewAccount(role))
+}
+
+// checkRole returns ErrRoleDoesNotExist unless RoleExistsContext takes the
+// name for a role. Roles and users share a namespace, so this keeps the role
+// methods from acting on a user account.
+func (c *MySQLController) checkRole(ctx context.Context, role string) error {
+	ok, err := c.RoleExistsContext(ctx, role)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrRoleDoesNotExist
+	}
+	return nil
+}
+
+// GrantRole grants the role to the given user.
+func (c *MySQLController) GrantRole(role, username string) error {
+	return c.GrantRoleContext(context.Background(), role, username)
+}
+
+// GrantRoleContext grants the role to the given user.
+func (c *MySQLController) GrantRoleContext(ctx context.Context, role, username string) error {
+	return c.GrantRoleToAccountContext(ctx, role, NewAccount(username))
+}
+
+// GrantRoleToAccount grants the role to the given account.
+func (c *MySQLController) GrantRoleToAccount(role string, account Account) error {
+	return c.GrantRoleToAccountContext(context.Background(), role, account)
+}
+
+// GrantRoleToAccountContext grants the role to the given account.
+func (c *MySQLController) GrantRoleToAccountContext(ctx context.Context, role string, account Account) error {
+	err := validateRoleGrant(role, account)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, "GRANT "+quoteAccount(NewAccount(role))+" TO "+quoteAccount(account))
+	if err != nil {
+		return fmt.Errorf("error granting role: %w", classifyError(err, map[uint16]error{erUnknownAuthID: ErrRoleDoesNotExist}))
+	}
+	return nil
+}
+
+// RevokeRole revokes the role from the given user.
+func (c *MySQLController) RevokeRole(role, username string) error {
+	return c.RevokeRoleContext(context.Background(), role, username)
+}
+
+// RevokeRoleContext revokes the role from the given user.
+func (c *MySQLController) RevokeRoleContext(ctx context.Context, role, username string) error {
+	return c.RevokeRoleFromAccountContext(ctx, role, NewAccount(username))
+}
+
+// RevokeRoleFromAccount revokes the role from the given account.
+func (c *MySQLController) RevokeRoleFromAccount(role string, account Account) error {
+	return c.RevokeRoleFromAccountContext(context.Background(), role, account)
+}
+
+// RevokeRoleFromAccountContext revokes the role from the given account.
+func (c *MySQLController) RevokeRoleFromAccountContext(ctx context.Context, role string, account Account) error {
+	err := validateRoleGrant(role, account)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, "REVOKE "+quoteAccount(NewAccount(role))+" FROM "+quoteAccount(account))
+	if err != nil {
+		return fmt.Errorf("error revoking role: %w", classifyError(err, map[uint16]error{erUnknownAuthID: ErrRoleDoesNotExist}))
+	}
+	return nil
+}
+
+// SetDefaultRoles sets the roles activated when the given user connects.
+func (c *MySQLController) SetDefaultRoles(username string, roles ...string) error {
+	return c.SetDefaultRolesContext(context.Background(), username, roles...)
+}
+
+// SetDefaultRolesContext sets the roles activated when the given user connects.
+func (c *MySQLController) SetDefaultRolesContext(ctx context.Context, username string, roles ...string) error {
+	return c.SetDefaultRolesForAccountContext(ctx, NewAccount(username), roles...)
+}
+
+// SetDefaultRolesForAccount sets the roles activated when the given account connects.
+func (c *MySQLController) SetDefaultRolesForAccount(account Account, roles ...string) error {
+	return c.SetDefaultRolesForAccountContext(context.Background(), account, roles...)
+}
+
+// SetDefaultRolesForAccountContext sets the roles activated when the given
+// account connects. The roles must have been granted to the account; no roles
+// clears the defaults.
+func (c *MySQLController) SetDefaultRolesForAccountContext(ctx context.Context, account Account, roles ...string) error {
+	err := validateAccount(account)
+	if err != nil {
+		return fmt.Errorf("error validating account: %w", err)
+	}
+
+	quoted := make([]string, len(roles))
+	for i, role := range roles {
+		err = validateRoleName(role)
+		if err != nil {
+			return err
+		}
+		quoted[i] = quoteAccount(NewAccount(role))
+	}
+
+	list := "NONE"
+	if len(roles) > 0 {
+		list = strings.Join(quoted, ", ")
+	}
+
+	_, err = c.db.ExecContext(ctx, "SET DEFAULT ROLE "+list+" TO "+quoteAccount(account))
+	if err != nil {
+		return fmt.Errorf("error setting default roles: %w", classifyError(err, map[uint16]error{erCannotUser: ErrUserDoesNotExist}))
+	}
+	return nil
+}
+
+// UserRoles returns the roles granted to the given user.
+func (c *MySQLController) UserRoles(username string) ([]UserRole, error) {
+	return c.UserRolesContext(context.Background(), username)
+}
+
+// UserRolesContext returns the roles granted to the given user.
+func (c *MySQLController) UserRolesContext(ctx context.Context, username string) ([]UserRole, error) {
+	return c.AccountRolesContext(ctx, NewAccount(username))
+}
+
+// AccountRoles returns the roles granted to the given account.
+func (c *MySQLController) AccountRoles(account Account) ([]UserRole, error) {
+	return c.AccountRolesContext(context.Background(), account)
+}
+
+// AccountRolesContext returns the roles granted to the given account.
+func (c *MySQLController) AccountRolesContext(ctx context.Context, account Account) ([]UserRole, error) {
+	err := validateAccount(account)
+	if err != nil {
+		return nil, fmt.Errorf("error validating account: %w", err)
+	}
+
+	q := "SELECT e.FROM_USER, d.DEFAULT_ROLE_USER IS NOT NULL FROM mysql.role_edges e" +
+		" LEFT JOIN mysql.default_roles d ON d.USER = e.TO_USER AND d.HOST = e.TO_HOST AND d.DEFAULT_ROLE_USER = e.FROM_USER AND d.DEFAULT_ROLE_HOST = e.FROM_HOST" +
+		" WHERE e.TO_USER = ? AND e.TO_HOST = ? AND e.FROM_HOST = ? ORDER BY e.FROM_USER"
+	rows, err := c.db.QueryContext(ctx, q, account.User, account.Host, DefaultHost)
+	if err != nil {
+		return nil, fmt.Errorf("error listing roles: %w", classifyError(err, nil))
+	}
+	defer rows.Close()
+
+	var roles []UserRole
+	for rows.Next() {
+		var role UserRole
+		err = rows.Scan(&role.Name, &role.Default)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// validateRoleName validates a role name. Roles share the namespace of users.
+func validateRoleName(role string) error {
+	if role == "" {
+		return fmt.Errorf("role name cannot be empty")
+	}
+
+	if contains(baseUsers, role) {
+		return fmt.Errorf("role name %s is reserved", role)
+	}
+	return nil
+}
+
+// validateRoleGrant validates the arguments of granting a role to an account
+func validateRoleGrant(role string, account Account) error {
+	err := validateRoleName(role)
+	if err != nil {
+		return fmt.Errorf("error validating role: %w", err)
+	}
+
+	err = validateAccount(account)
+	if err != nil {
+		return fmt.Errorf("error validating account: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRole = "test-role"
+
+func TestMySQLController_Roles(t *testing.T) {
+	c := createTestController()
+
+	err := c.CreateRole(testRole)
+	assert.NoError(t, err)
+	defer c.DropRole(testRole)
+
+	err = c.CreateRole(testRole)
+	assert.Equal(t, ErrRoleExists, err)
+
+	b, err := c.RoleExists(testRole)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	roles, err := c.ListRoles()
+	assert.NoError(t, err)
+	assert.Contains(t, roles, testRole)
+
+	err = c.DropRole(testRole)
+	assert.NoError(t, err)
+
+	err = c.DropRole(testRole)
+	assert.Equal(t, ErrRoleDoesNotExist, err)
+
+	b, err = c.RoleExists(testRole)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.CreateRole("root")
+	assert.Error(t, err)
+}
+
+func TestMySQLController_RoleMethodsRefuseUsers(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	err := c.GrantToRole("select", testDB, testUser)
+	assert.Equal(t, ErrRoleDoesNotExist, err)
+
+	b, err := c.GrantExists("select", testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.Grant("select", testDB, testUser)
+	assert.NoError(t, err)
+
+	err = c.RevokeFromRole("select", testDB, testUser)
+	assert.Equal(t, ErrRoleDoesNotExist, err)
+
+	err = c.DropRole(testUser)
+	assert.Equal(t, ErrRoleDoesNotExist, err)
+
+	b, err = c.UserExists(testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+}
+
+func TestMySQLController_GrantToRole(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateRole(testRole)
+	defer c.DropRole(testRole)
+
+	err := c.GrantToRole("select", testDB, testRole)
+	assert.NoError(t, err)
+
+	b, err := c.GrantExists("select", testDB, testRole)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	err = c.RevokeFromRole("select", testDB, testRole)
+	assert.NoError(t, err)
+
+	b, err = c.GrantExists("select", testDB, testRole)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.GrantToRole("not-a-grant", testDB, testRole)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestMySQLController_UserRoles(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+	c.CreateRole(testRole)
+	defer c.DropRole(testRole)
+
+	roles, err := c.UserRoles(testUser)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+
+	err = c.GrantRole(testRole, testUser)
+	assert.NoError(t, err)
+
+	roles, err = c.UserRoles(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []UserRole{{Name: testRole}}, roles)
+
+	err = c.SetDefaultRoles(testUser, testRole)
+	assert.NoError(t, err)
+
+	roles, err = c.UserRoles(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []UserRole{{Name: testRole, Default: true}}, roles)
+
+	err = c.SetDefaultRoles(testUser)
+	assert.NoError(t, err)
+
+	roles, err = c.UserRoles(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []UserRole{{Name: testRole}}, roles)
+
+	err = c.RevokeRole(testRole, testUser)
+	assert.NoError(t, err)
+
+	roles, err = c.UserRoles(testUser)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+
+	err = c.GrantRole("missing-role", testUser)
+	assert.ErrorIs(t, err, ErrRoleDoesNotExist)
+}
+
+func Test_validateRoleName(t *testing.T) {
+	assert.NoError(t, validateRoleName(testRole))
+	assert.Error(t, validateRoleName(""))
+	assert.Error(t, validateRoleName("mysql.sys"))
+
+	assert.NoError(t, validateRoleGrant(testRole, NewAccount(testUser)))
+	assert.Error(t, validateRoleGrant(testRole, Account{User: testUser}))
+}
+
+func TestMySQLController_ListRolesGranted(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+	c.CreateRole(testRole)
+	defer c.DropRole(testRole)
+
+	err := c.GrantRole(testRole, testUser)
+	assert.NoError(t, err)
+
+	// granted roles are recognised even when no longer locked
+	err = c.UnlockUser(testRole)
+	assert.NoError(t, err)
+
+	b, err := c.RoleExists(testRole)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	roles, err := c.ListRoles()
+	assert.NoError(t, err)
+	assert.NotContains(t, roles, testUser)
+}
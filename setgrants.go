@@ -0,0 +1,84 @@
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GrantChanges reports the grants changed by SetGrants.
+type GrantChanges struct {
+	Granted []string
+	Revoked []string
+}
+
+// Changed returns true if any grant was granted or revoked.
+func (g GrantChanges) Changed() bool {
+	return len(g.Granted) > 0 || len(g.Revoked) > 0
+}
+
+// SetGrants makes the given grants the only grants of the given user on the given database
+func (c *MySQLController) SetGrants(dbName, username string, grantNames []string) (GrantChanges, error) {
+	return c.SetGrantsContext(context.Background(), dbName, username, grantNames)
+}
+
+// SetGrantsContext makes the given grants the only grants of the given user on the given database
+func (c *MySQLController) SetGrantsContext(ctx context.Context, dbName, username string, grantNames []string) (GrantChanges, error) {
+	return c.SetGrantsForAccountContext(ctx, dbName, NewAccount(username), grantNames)
+}
+
+// SetGrantsForAccount makes the given grants the only grants of the given account on the given database
+func (c *MySQLController) SetGrantsForAccount(dbName string, account Account, grantNames []string) (GrantChanges, error) {
+	return c.SetGrantsForAccountContext(context.Background(), dbName, account, grantNames)
+}
+
+// SetGrantsForAccountContext makes the given grants the only grants of the
+// given account on the given database. It compares them with mysql.db and
+// only issues the GRANT and REVOKE statements needed, returning what changed.
+// An empty list revokes every grant.
+func (c *MySQLController) SetGrantsForAccountContext(ctx context.Context, dbName string, account Account, grantNames []string) (GrantChanges, error) {
+	desired := map[string]bool{}
+	for _, grantName := range grantNames {
+		grantName = strings.ToUpper(grantName)
+		err := validateGrant(grantName)
+		if err != nil {
+			return GrantChanges{}, fmt.Errorf("error validating grant: %w", err)
+		}
+		desired[grantName] = true
+	}
+
+	current, err := c.databaseGrants(ctx, dbName, account)
+	if err != nil {
+		return GrantChanges{}, err
+	}
+
+	var changes GrantChanges
+	for _, grantName := range current {
+		if !desired[grantName] {
+			changes.Revoked = append(changes.Revoked, grantName)
+		}
+		delete(desired, grantName)
+	}
+	for grantName := range desired {
+		changes.Granted = append(changes.Granted, grantName)
+	}
+	sort.Strings(changes.Granted)
+
+	if len(changes.Granted) > 0 {
+		err = c.grantPrivileges(ctx, changes.Granted, dbName, account)
+		if err != nil {
+			return GrantChanges{}, err
+		}
+	}
+
+	if len(changes.Revoked) > 0 {
+		err = c.revokePrivileges(ctx, changes.Revoked, dbName, account)
+		if err != nil {
+			// the grants were applied, report them
+			return GrantChanges{Granted: changes.Granted}, err
+		}
+	}
+
+	return changes, nil
+}
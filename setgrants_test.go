@@ -0,0 +1,48 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_SetGrants(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	changes, err := c.SetGrants(testDB, testUser, []string{"select", "insert"})
+	assert.NoError(t, err)
+	assert.Equal(t, GrantChanges{Granted: []string{"INSERT", "SELECT"}}, changes)
+	assert.True(t, changes.Changed())
+
+	changes, err = c.SetGrants(testDB, testUser, []string{"INSERT", "SELECT"})
+	assert.NoError(t, err)
+	assert.False(t, changes.Changed())
+
+	changes, err = c.SetGrants(testDB, testUser, []string{"SELECT", "UPDATE", "SHOW VIEW"})
+	assert.NoError(t, err)
+	assert.Equal(t, GrantChanges{Granted: []string{"SHOW VIEW", "UPDATE"}, Revoked: []string{"INSERT"}}, changes)
+
+	b, err := c.GrantExists("insert", testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	b, err = c.GrantExists("update", testDB, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	changes, err = c.SetGrants(testDB, testUser, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, GrantChanges{Revoked: []string{"SELECT", "SHOW VIEW", "UPDATE"}}, changes)
+
+	changes, err = c.SetGrants(testDB, testUser, []string{"SELECT", "SUPER"})
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+	assert.False(t, changes.Changed())
+
+	b, err = c.GrantExists("select", testDB, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+}
@@ -0,0 +1,269 @@
+package mysqlctl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GrantTable grants the given grant on a single table to the given user
+func (c *MySQLController) GrantTable(grantName, dbName, tableName, username string) error {
+	return c.GrantTableContext(context.Background(), grantName, dbName, tableName, username)
+}
+
+// GrantTableContext grants the given grant on a single table to the given user
+func (c *MySQLController) GrantTableContext(ctx context.Context, grantName, dbName, tableName, username string) error {
+	return c.GrantTableToAccountContext(ctx, grantName, dbName, tableName, NewAccount(username))
+}
+
+// GrantTableToAccount grants the given grant on a single table to the given account
+func (c *MySQLController) GrantTableToAccount(grantName, dbName, tableName string, account Account) error {
+	return c.GrantTableToAccountContext(context.Background(), grantName, dbName, tableName, account)
+}
+
+// GrantTableToAccountContext grants the given grant on a single table to the given account
+func (c *MySQLController) GrantTableToAccountContext(ctx context.Context, grantName, dbName, tableName string, account Account) error {
+	grantName, err := validateTableGrant(grantName, dbName, tableName, account, tableGrants)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf("GRANT %s ON %s.%s TO %s", grantName, quoteIdentifier(dbName), quoteIdentifier(tableName), quoteAccount(account))
+	_, err = c.db.ExecContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error granting privileges: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// RevokeTable revokes the given grant on a single table from the given user
+func (c *MySQLController) RevokeTable(grantName, dbName, tableName, username string) error {
+	return c.RevokeTableContext(context.Background(), grantName, dbName, tableName, username)
+}
+
+// RevokeTableContext revokes the given grant on a single table from the given user
+func (c *MySQLController) RevokeTableContext(ctx context.Context, grantName, dbName, tableName, username string) error {
+	return c.RevokeTableFromAccountContext(ctx, grantName, dbName, tableName, NewAccount(username))
+}
+
+// RevokeTableFromAccount revokes the given grant on a single table from the given account
+func (c *MySQLController) RevokeTableFromAccount(grantName, dbName, tableName string, account Account) error {
+	return c.RevokeTableFromAccountContext(context.Background(), grantName, dbName, tableName, account)
+}
+
+// RevokeTableFromAccountContext revokes the given grant on a single table from the given account
+func (c *MySQLController) RevokeTableFromAccountContext(ctx context.Context, grantName, dbName, tableName string, account Account) error {
+	grantName, err := validateTableGrant(grantName, dbName, tableName, account, tableGrants)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf("REVOKE %s ON %s.%s FROM %s", grantName, quoteIdentifier(dbName), quoteIdentifier(tableName), quoteAccount(account))
+	_, err = c.db.ExecContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error revoking privileges: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// TableGrantExists returns true if the given grant exists on the table for the given user
+func (c *MySQLController) TableGrantExists(grantName, dbName, tableName, username string) (bool, error) {
+	return c.TableGrantExistsContext(context.Background(), grantName, dbName, tableName, username)
+}
+
+// TableGrantExistsContext returns true if the given grant exists on the table for the given user
+func (c *MySQLController) TableGrantExistsContext(ctx context.Context, grantName, dbName, tableName, username string) (bool, error) {
+	return c.TableGrantExistsForAccountContext(ctx, grantName, dbName, tableName, NewAccount(username))
+}
+
+// TableGrantExistsForAccount returns true if the given grant exists on the table for the given account
+func (c *MySQLController) TableGrantExistsForAccount(grantName, dbName, tableName string, account Account) (bool, error) {
+	return c.TableGrantExistsForAccountContext(context.Background(), grantName, dbName, tableName, account)
+}
+
+// TableGrantExistsForAccountContext returns true if the given grant exists on
+// the table for the given account. Only table-level grants (mysql.tables_priv)
+// are considered.
+func (c *MySQLController) TableGrantExistsForAccountContext(ctx context.Context, grantName, dbName, tableName string, account Account) (bool, error) {
+	grantName, err := validateTableGrant(grantName, dbName, tableName, account, tableGrants)
+	if err != nil {
+		return false, err
+	}
+
+	q := "SELECT COUNT(*) FROM mysql.tables_priv WHERE Db = ? AND User = ? AND Host = ? AND Table_name = ? AND FIND_IN_SET(?, Table_priv) > 0"
+	var count int
+	err = c.db.QueryRowContext(ctx, q, dbName, account.User, account.Host, tableName, tableGrants[grantName]).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error checking if grant exists: %w", classifyError(err, nil))
+	}
+
+	return count > 0, nil
+}
+
+// GrantColumns grants the given grant on columns of a table to the given user
+func (c *MySQLController) GrantColumns(grantName, dbName, tableName string, columns []string, username string) error {
+	return c.GrantColumnsContext(context.Background(), grantName, dbName, tableName, columns, username)
+}
+
+// GrantColumnsContext grants the given grant on columns of a table to the given user
+func (c *MySQLController) GrantColumnsContext(ctx context.Context, grantName, dbName, tableName string, columns []string, username string) error {
+	return c.GrantColumnsToAccountContext(ctx, grantName, dbName, tableName, columns, NewAccount(username))
+}
+
+// GrantColumnsToAccount grants the given grant on columns of a table to the given account
+func (c *MySQLController) GrantColumnsToAccount(grantName, dbName, tableName string, columns []string, account Account) error {
+	return c.GrantColumnsToAccountContext(context.Background(), grantName, dbName, tableName, columns, account)
+}
+
+// GrantColumnsToAccountContext grants the given grant on columns of a table to
+// the given account, e.g. SELECT (email, name) ON app.users.
+func (c *MySQLController) GrantColumnsToAccountContext(ctx context.Context, grantName, dbName, tableName string, columns []string, account Account) error {
+	grantName, err := validateColumnGrant(grantName, dbName, tableName, columns, account)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf("GRANT %s (%s) ON %s.%s TO %s", grantName, quoteColumns(columns), quoteIdentifier(dbName), quoteIdentifier(tableName), quoteAccount(account))
+	_, err = c.db.ExecContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error granting privileges: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// RevokeColumns revokes the given grant on columns of a table from the given user
+func (c *MySQLController) RevokeColumns(grantName, dbName, tableName string, columns []string, username string) error {
+	return c.RevokeColumnsContext(context.Background(), grantName, dbName, tableName, columns, username)
+}
+
+// RevokeColumnsContext revokes the given grant on columns of a table from the given user
+func (c *MySQLController) RevokeColumnsContext(ctx context.Context, grantName, dbName, tableName string, columns []string, username string) error {
+	return c.RevokeColumnsFromAccountContext(ctx, grantName, dbName, tableName, columns, NewAccount(username))
+}
+
+// RevokeColumnsFromAccount revokes the given grant on columns of a table from the given account
+func (c *MySQLController) RevokeColumnsFromAccount(grantName, dbName, tableName string, columns []string, account Account) error {
+	return c.RevokeColumnsFromAccountContext(context.Background(), grantName, dbName, tableName, columns, account)
+}
+
+// RevokeColumnsFromAccountContext revokes the given grant on columns of a table from the given account
+func (c *MySQLController) RevokeColumnsFromAccountContext(ctx context.Context, grantName, dbName, tableName string, columns []string, account Account) error {
+	grantName, err := validateColumnGrant(grantName, dbName, tableName, columns, account)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf("REVOKE %s (%s) ON %s.%s FROM %s", grantName, quoteColumns(columns), quoteIdentifier(dbName), quoteIdentifier(tableName), quoteAccount(account))
+	_, err = c.db.ExecContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error revoking privileges: %w", classifyError(err, nil))
+	}
+	return nil
+}
+
+// ColumnGrantExists returns true if the given grant exists on every column for the given user
+func (c *MySQLController) ColumnGrantExists(grantName, dbName, tableName string, columns []string, username string) (bool, error) {
+	return c.ColumnGrantExistsContext(context.Background(), grantName, dbName, tableName, columns, username)
+}
+
+// ColumnGrantExistsContext returns true if the given grant exists on every column for the given user
+func (c *MySQLController) ColumnGrantExistsContext(ctx context.Context, grantName, dbName, tableName string, columns []string, username string) (bool, error) {
+	return c.ColumnGrantExistsForAccountContext(ctx, grantName, dbName, tableName, columns, NewAccount(username))
+}
+
+// ColumnGrantExistsForAccount returns true if the given grant exists on every column for the given account
+func (c *MySQLController) ColumnGrantExistsForAccount(grantName, dbName, tableName string, columns []string, account Account) (bool, error) {
+	return c.ColumnGrantExistsForAccountContext(context.Background(), grantName, dbName, tableName, columns, account)
+}
+
+// ColumnGrantExistsForAccountContext returns true if the given grant exists on
+// every column for the given account. Only column-level grants
+// (mysql.columns_priv) are considered.
+func (c *MySQLController) ColumnGrantExistsForAccountContext(ctx context.Context, grantName, dbName, tableName string, columns []string, account Account) (bool, error) {
+	grantName, err := validateColumnGrant(grantName, dbName, tableName, columns, account)
+	if err != nil {
+		return false, err
+	}
+
+	columns = uniqueColumns(columns)
+	args := []interface{}{dbName, account.User, account.Host, tableName, columnGrants[grantName]}
+	for _, column := range columns {
+		args = append(args, column)
+	}
+
+	q := "SELECT COUNT(*) FROM mysql.columns_priv WHERE Db = ? AND User = ? AND Host = ? AND Table_name = ? AND FIND_IN_SET(?, Column_priv) > 0" +
+		" AND Column_name IN (?" + strings.Repeat(", ?", len(columns)-1) + ")"
+	var count int
+	err = c.db.QueryRowContext(ctx, q, args...).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error checking if grant exists: %w", classifyError(err, nil))
+	}
+
+	return count == len(columns), nil
+}
+
+// validateTableGrant validates the arguments of a table-level grant and
+// returns the normalized grant name
+func validateTableGrant(grantName, dbName, tableName string, account Account, allowed map[string]string) (string, error) {
+	err := validateDBName(dbName)
+	if err != nil {
+		return "", fmt.Errorf("error validating database name: %w", err)
+	}
+
+	if tableName == "" {
+		return "", fmt.Errorf("error validating table name: table name cannot be empty")
+	}
+
+	err = validateAccount(account)
+	if err != nil {
+		return "", fmt.Errorf("error validating account: %w", err)
+	}
+
+	grantName = strings.ToUpper(grantName)
+	err = validateGrantIn(grantName, allowed)
+	if err != nil {
+		return "", fmt.Errorf("error validating grant: %w", err)
+	}
+
+	return grantName, nil
+}
+
+// validateColumnGrant validates the arguments of a column-level grant and
+// returns the normalized grant name
+func validateColumnGrant(grantName, dbName, tableName string, columns []string, account Account) (string, error) {
+	if len(columns) == 0 {
+		return "", fmt.Errorf("error validating columns: no columns given")
+	}
+
+	for _, column := range columns {
+		if column == "" {
+			return "", fmt.Errorf("error validating columns: column name cannot be empty")
+		}
+	}
+
+	return validateTableGrant(grantName, dbName, tableName, account, columnGrants)
+}
+
+// quoteColumns returns the comma separated quoted column names
+func quoteColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = quoteIdentifier(column)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// uniqueColumns returns the columns without duplicates, compared
+// case-insensitively like MySQL compares column names
+func uniqueColumns(columns []string) []string {
+	var unique []string
+	seen := map[string]bool{}
+	for _, column := range columns {
+		key := strings.ToLower(column)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, column)
+		}
+	}
+	return unique
+}
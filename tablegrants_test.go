@@ -0,0 +1,130 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_TableGrants(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+
+	_, err := c.db.Exec("CREATE TABLE `test-db`.users (id INT, name VARCHAR(255), email VARCHAR(255), password VARCHAR(255))")
+	assert.NoError(t, err)
+
+	for g := range tableGrants {
+		err := c.GrantTable(g, testDB, "users", testUser)
+		assert.NoError(t, err)
+
+		b, err := c.TableGrantExists(g, testDB, "users", testUser)
+		assert.NoError(t, err)
+		assert.True(t, b)
+
+		b, err = c.GrantExists(g, testDB, testUser)
+		assert.NoError(t, err)
+		assert.False(t, b)
+	}
+
+	for g := range tableGrants {
+		err := c.RevokeTable(g, testDB, "users", testUser)
+		assert.NoError(t, err)
+
+		b, err := c.TableGrantExists(g, testDB, "users", testUser)
+		assert.NoError(t, err)
+		assert.False(t, b)
+	}
+
+	err = c.RevokeTable("select", testDB, "users", testUser)
+	assert.ErrorIs(t, err, ErrGrantDoesNotExist)
+
+	err = c.GrantTable("event", testDB, "users", testUser)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func TestMySQLController_ColumnGrants(t *testing.T) {
+	c := createTestController()
+	c.CreateUser(testUser, testPassword)
+	defer c.DeleteUser(testUser)
+
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+
+	_, err := c.db.Exec("CREATE TABLE `test-db`.users (id INT, name VARCHAR(255), email VARCHAR(255), password VARCHAR(255))")
+	assert.NoError(t, err)
+
+	err = c.GrantColumns("select", testDB, "users", []string{"email", "name"}, testUser)
+	assert.NoError(t, err)
+
+	b, err := c.ColumnGrantExists("select", testDB, "users", []string{"email", "name", "email"}, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = c.ColumnGrantExists("select", testDB, "users", []string{"email", "password"}, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	b, err = c.ColumnGrantExists("update", testDB, "users", []string{"email"}, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	db, err := openMySQLWithDB(testUser, testPassword, testDB)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("SELECT email, name FROM users")
+	assert.NoError(t, err)
+
+	_, err = db.Exec("SELECT password FROM users")
+	assert.Error(t, err)
+
+	err = c.RevokeColumns("select", testDB, "users", []string{"name"}, testUser)
+	assert.NoError(t, err)
+
+	b, err = c.ColumnGrantExists("select", testDB, "users", []string{"email"}, testUser)
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = c.ColumnGrantExists("select", testDB, "users", []string{"name"}, testUser)
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	err = c.GrantColumns("delete", testDB, "users", []string{"email"}, testUser)
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+
+	err = c.GrantColumns("select", testDB, "users", nil, testUser)
+	assert.Error(t, err)
+}
+
+func Test_validateColumnGrant(t *testing.T) {
+	g, err := validateColumnGrant("select", testDB, "users", []string{"email"}, NewAccount(testUser))
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT", g)
+
+	_, err = validateColumnGrant("select", testDB, "", []string{"email"}, NewAccount(testUser))
+	assert.Error(t, err)
+
+	_, err = validateColumnGrant("select", testDB, "users", []string{"email", ""}, NewAccount(testUser))
+	assert.Error(t, err)
+
+	_, err = validateColumnGrant("drop", testDB, "users", []string{"email"}, NewAccount(testUser))
+	assert.ErrorIs(t, err, ErrInvalidGrant)
+}
+
+func Test_quoteColumns(t *testing.T) {
+	assert.Equal(t, "`email`, `na``me`", quoteColumns([]string{"email", "na`me"}))
+	assert.Equal(t, []string{"email", "name"}, uniqueColumns([]string{"email", "name", "EMAIL"}))
+}
+
+func Test_tableGrants(t *testing.T) {
+	for g := range tableGrants {
+		assert.NoError(t, validateGrant(g))
+	}
+	for g := range columnGrants {
+		assert.NoError(t, validateGrantIn(g, tableGrants))
+	}
+}
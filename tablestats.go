@@ -0,0 +1,87 @@
+package mysqlctl
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// TableStats are the statistics of a table, from information_schema.tables.
+// MySQL 8 caches them for information_schema_stats_expiry seconds.
+type TableStats struct {
+	Name   string
+	Engine string
+	// Rows is an estimate for InnoDB tables.
+	Rows        int64
+	DataLength  int64
+	IndexLength int64
+	DataFree    int64
+	// AutoIncrement is the next AUTO_INCREMENT value, nil if the table has no
+	// AUTO_INCREMENT column.
+	AutoIncrement *int64
+	CreateTime    time.Time
+	// UpdateTime is the zero time if the engine does not track it or the
+	// table was not modified since the server started.
+	UpdateTime time.Time
+	Collation  string
+}
+
+// Size returns the data and index size of the table in Bytes.
+func (s TableStats) Size() int64 {
+	return s.DataLength + s.IndexLength
+}
+
+// TableStats returns the statistics of every table in the database.
+func (c *MySQLController) TableStats(dbName string) ([]TableStats, error) {
+	return c.TableStatsContext(context.Background(), dbName)
+}
+
+// TableStatsContext returns the statistics of every table in the database,
+// sorted by name. Views are left out.
+func (c *MySQLController) TableStatsContext(ctx context.Context, dbName string) ([]TableStats, error) {
+	err := validateDBName(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	q := "SELECT table_name, engine, table_rows, data_length, index_length, data_free, auto_increment," +
+		" UNIX_TIMESTAMP(create_time), UNIX_TIMESTAMP(update_time), table_collation" +
+		" FROM information_schema.tables WHERE table_schema = ? AND table_type = 'BASE TABLE' ORDER BY table_name"
+	rows, err := c.db.QueryContext(ctx, q, dbName)
+	if err != nil {
+		return nil, classifyError(err, nil)
+	}
+	defer rows.Close()
+
+	var stats []TableStats
+	for rows.Next() {
+		var (
+			s                                      TableStats
+			engine, collation                      sql.NullString
+			tableRows, data, index, free, autoIncr sql.NullInt64
+			createTime, updateTime                 sql.NullInt64
+		)
+		err = rows.Scan(&s.Name, &engine, &tableRows, &data, &index, &free, &autoIncr, &createTime, &updateTime, &collation)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Engine = engine.String
+		s.Rows = tableRows.Int64
+		s.DataLength = data.Int64
+		s.IndexLength = index.Int64
+		s.DataFree = free.Int64
+		if autoIncr.Valid {
+			s.AutoIncrement = &autoIncr.Int64
+		}
+		if createTime.Valid {
+			s.CreateTime = time.Unix(createTime.Int64, 0)
+		}
+		if updateTime.Valid {
+			s.UpdateTime = time.Unix(updateTime.Int64, 0)
+		}
+		s.Collation = collation.String
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
@@ -0,0 +1,46 @@
+package mysqlctl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLController_TableStats(t *testing.T) {
+	c := createTestController()
+	c.CreateDatabase(testDB)
+	defer c.DeleteDatabase(testDB)
+
+	stats, err := c.TableStats(testDB)
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+
+	_, err = c.db.Exec("CREATE TABLE `test-db`.users (id INT AUTO_INCREMENT PRIMARY KEY, email VARCHAR(255)) ENGINE=InnoDB COLLATE=utf8mb4_bin")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("CREATE TABLE `test-db`.events (name VARCHAR(255)) ENGINE=MyISAM")
+	assert.NoError(t, err)
+	_, err = c.db.Exec("CREATE VIEW `test-db`.emails AS SELECT email FROM `test-db`.users")
+	assert.NoError(t, err)
+
+	stats, err = c.TableStats(testDB)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+
+	assert.Equal(t, "events", stats[0].Name)
+	assert.Equal(t, "MyISAM", stats[0].Engine)
+	assert.Nil(t, stats[0].AutoIncrement)
+
+	assert.Equal(t, "users", stats[1].Name)
+	assert.Equal(t, "InnoDB", stats[1].Engine)
+	assert.Equal(t, "utf8mb4_bin", stats[1].Collation)
+	assert.Greater(t, stats[1].DataLength, int64(0))
+	assert.Equal(t, stats[1].DataLength+stats[1].IndexLength, stats[1].Size())
+	if assert.NotNil(t, stats[1].AutoIncrement) {
+		assert.Equal(t, int64(1), *stats[1].AutoIncrement)
+	}
+	assert.WithinDuration(t, time.Now(), stats[1].CreateTime, time.Hour)
+
+	_, err = c.TableStats("")
+	assert.Error(t, err)
+}
@@ -1,22 +1,96 @@
 package mysqlctl
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 )
 
 type UserController interface {
-	CreateUser(username, password string) error
-	UpdateUserPassword(username, password string) error
+	CreateUser(username, password string, opts ...UserOption) error
+	CreateUserWithGeneratedPassword(username string, opts ...UserOption) (Credentials, error)
+	UpdateUserPassword(username, password string, opts ...UserOption) error
+	BeginUserPasswordRotation(username, password string) error
+	CompleteUserPasswordRotation(username string) error
 	DeleteUser(username string) error
 	ListUsers() ([]string, error)
 	UserExists(username string) (bool, error)
 	CreateUserWithMaxConn(username, password string, maxConn int) error
 	UpdateUserMaxConn(username string, maxConn int) error
 	GetUserMaxConn(username string) (int, error)
+	UserAuthPlugin(username string) (string, error)
+	UpdateUserResourceLimits(username string, limits ResourceLimits) error
+	GetUserResourceLimits(username string) (ResourceLimits, error)
+	LockUser(username string) error
+	UnlockUser(username string) error
+	SetUserPasswordExpiry(username string, expiry PasswordExpiry) error
+	SetUserFailedLoginTracking(username string, f FailedLoginTracking) error
+	GetUserStatus(username string) (AccountStatus, error)
+
+	CreateAccount(account Account, password string, opts ...UserOption) error
+	CreateAccountWithGeneratedPassword(account Account, opts ...UserOption) (Credentials, error)
+	UpdateAccountPassword(account Account, password string, opts ...UserOption) error
+	BeginAccountPasswordRotation(account Account, password string) error
+	CompleteAccountPasswordRotation(account Account) error
+	DeleteAccount(account Account) error
+	ListAccounts() ([]Account, error)
+	AccountExists(account Account) (bool, error)
+	AccountAuthPlugin(account Account) (string, error)
+	UpdateAccountResourceLimits(account Account, limits ResourceLimits) error
+	GetAccountResourceLimits(account Account) (ResourceLimits, error)
+	LockAccount(account Account) error
+	UnlockAccount(account Account) error
+	SetAccountPasswordExpiry(account Account, expiry PasswordExpiry) error
+	SetAccountFailedLoginTracking(account Account, f FailedLoginTracking) error
+	GetAccountStatus(account Account) (AccountStatus, error)
+}
+
+// UserControllerContext is the context-aware counterpart of UserController.
+type UserControllerContext interface {
+	CreateUserContext(ctx context.Context, username, password string, opts ...UserOption) error
+	CreateUserWithGeneratedPasswordContext(ctx context.Context, username string, opts ...UserOption) (Credentials, error)
+	UpdateUserPasswordContext(ctx context.Context, username, password string, opts ...UserOption) error
+	BeginUserPasswordRotationContext(ctx context.Context, username, password string) error
+	CompleteUserPasswordRotationContext(ctx context.Context, username string) error
+	DeleteUserContext(ctx context.Context, username string) error
+	ListUsersContext(ctx context.Context) ([]string, error)
+	UserExistsContext(ctx context.Context, username string) (bool, error)
+	CreateUserWithMaxConnContext(ctx context.Context, username, password string, maxConn int) error
+	UpdateUserMaxConnContext(ctx context.Context, username string, maxConn int) error
+	GetUserMaxConnContext(ctx context.Context, username string) (int, error)
+	UserAuthPluginContext(ctx context.Context, username string) (string, error)
+	UpdateUserResourceLimitsContext(ctx context.Context, username string, limits ResourceLimits) error
+	GetUserResourceLimitsContext(ctx context.Context, username string) (ResourceLimits, error)
+	LockUserContext(ctx context.Context, username string) error
+	UnlockUserContext(ctx context.Context, username string) error
+	SetUserPasswordExpiryContext(ctx context.Context, username string, expiry PasswordExpiry) error
+	SetUserFailedLoginTrackingContext(ctx context.Context, username string, f FailedLoginTracking) error
+	GetUserStatusContext(ctx context.Context, username string) (AccountStatus, error)
+
+	CreateAccountContext(ctx context.Context, account Account, password string, opts ...UserOption) error
+	CreateAccountWithGeneratedPasswordContext(ctx context.Context, account Account, opts ...UserOption) (Credentials, error)
+	UpdateAccountPasswordContext(ctx context.Context, account Account, password string, opts ...UserOption) error
+	BeginAccountPasswordRotationContext(ctx context.Context, account Account, password string) error
+	CompleteAccountPasswordRotationContext(ctx context.Context, account Account) error
+	DeleteAccountContext(ctx context.Context, account Account) error
+	ListAccountsContext(ctx context.Context) ([]Account, error)
+	AccountExistsContext(ctx context.Context, account Account) (bool, error)
+	AccountAuthPluginContext(ctx context.Context, account Account) (string, error)
+	UpdateAccountResourceLimitsContext(ctx context.Context, account Account, limits ResourceLimits) error
+	GetAccountResourceLimitsContext(ctx context.Context, account Account) (ResourceLimits, error)
+	LockAccountContext(ctx context.Context, account Account) error
+	UnlockAccountContext(ctx context.Context, account Account) error
+	SetAccountPasswordExpiryContext(ctx context.Context, account Account, expiry PasswordExpiry) error
+	SetAccountFailedLoginTrackingContext(ctx context.Context, account Account, f FailedLoginTracking) error
+	GetAccountStatusContext(ctx context.Context, account Account) (AccountStatus, error)
 }
 
-var _ UserController = &MySQLController{}
+var (
+	_ UserController        = &MySQLController{}
+	_ UserControllerContext = &MySQLController{}
+)
 
 var baseUsers = []string{"root", "mysql.sys", "mysql.session", "mysql.infoschema"}
 
@@ -25,45 +99,109 @@ var (
 	ErrUserDoesNotExist = fmt.Errorf("user does not exist")
 )
 
-func (c *MySQLController) CreateUser(username, password string) error {
-	err := validateUsername(username)
+func (c *MySQLController) CreateUser(username, password string, opts ...UserOption) error {
+	return c.CreateUserContext(context.Background(), username, password, opts...)
+}
+
+func (c *MySQLController) CreateUserContext(ctx context.Context, username, password string, opts ...UserOption) error {
+	return c.CreateAccountContext(ctx, NewAccount(username), password, opts...)
+}
+
+// CreateAccount creates the given 'user'@'host' account.
+func (c *MySQLController) CreateAccount(account Account, password string, opts ...UserOption) error {
+	return c.CreateAccountContext(context.Background(), account, password, opts...)
+}
+
+// CreateAccountContext creates the given 'user'@'host' account.
+func (c *MySQLController) CreateAccountContext(ctx context.Context, account Account, password string, opts ...UserOption) error {
+	err := validateAccount(account)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.db.Exec("CREATE USER `" + username + "` IDENTIFIED BY '" + password + "'")
+	o := newUserOptions(opts)
+	err = o.validate(password)
 	if err != nil {
-		if strings.Contains(err.Error(), "Error 1396") {
-			return ErrUserExists
+		return err
+	}
+
+	if o.needsPassword() {
+		err = c.checkPassword(account, password)
+		if err != nil {
+			return err
 		}
 	}
-	return err
+
+	err = c.execQuoted(ctx, func(quote func(string) string) string {
+		return "CREATE USER " + quoteAccount(account) + " " + o.identifiedClause(password, quote) + o.attributeClauses()
+	})
+	return classifyError(err, map[uint16]error{erCannotUser: ErrUserExists})
 }
 
-func (c *MySQLController) CreateUserWithMaxConn(username, password string, maxConn int) error {
-	err := validateUsername(username)
+// CreateUserWithGeneratedPassword creates the given user with a random
+// password and returns its credentials.
+func (c *MySQLController) CreateUserWithGeneratedPassword(username string, opts ...UserOption) (Credentials, error) {
+	return c.CreateUserWithGeneratedPasswordContext(context.Background(), username, opts...)
+}
+
+// CreateUserWithGeneratedPasswordContext creates the given user with a random
+// password and returns its credentials.
+func (c *MySQLController) CreateUserWithGeneratedPasswordContext(ctx context.Context, username string, opts ...UserOption) (Credentials, error) {
+	return c.CreateAccountWithGeneratedPasswordContext(ctx, NewAccount(username), opts...)
+}
+
+// CreateAccountWithGeneratedPassword creates the given account with a random
+// password and returns its credentials.
+func (c *MySQLController) CreateAccountWithGeneratedPassword(account Account, opts ...UserOption) (Credentials, error) {
+	return c.CreateAccountWithGeneratedPasswordContext(context.Background(), account, opts...)
+}
+
+// CreateAccountWithGeneratedPasswordContext creates the given account with a
+// random password and returns its credentials. The password is generated with
+// the configured PasswordGenerator until it satisfies the password policy.
+func (c *MySQLController) CreateAccountWithGeneratedPasswordContext(ctx context.Context, account Account, opts ...UserOption) (Credentials, error) {
+	err := validateAccount(account)
 	if err != nil {
-		return err
+		return Credentials{}, err
 	}
 
-	_, err = c.db.Exec("CREATE USER `" + username + "` IDENTIFIED BY '" + password + "' WITH MAX_USER_CONNECTIONS " + fmt.Sprintf("%d", maxConn))
+	if !newUserOptions(opts).needsPassword() {
+		return Credentials{}, fmt.Errorf("generated password cannot be used without password authentication")
+	}
+
+	password, err := c.generatePassword(account)
 	if err != nil {
-		if strings.Contains(err.Error(), "Error 1396") {
-			return ErrUserExists
-		}
+		return Credentials{}, err
 	}
-	return err
+
+	err = c.CreateAccountContext(ctx, account, password, opts...)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{Account: account, Password: password}, nil
+}
+
+func (c *MySQLController) CreateUserWithMaxConn(username, password string, maxConn int) error {
+	return c.CreateUserWithMaxConnContext(context.Background(), username, password, maxConn)
+}
+
+func (c *MySQLController) CreateUserWithMaxConnContext(ctx context.Context, username, password string, maxConn int) error {
+	return c.CreateUserContext(ctx, username, password, WithResourceLimits(ResourceLimits{MaxUserConnections: maxConn}))
 }
 
 func (c *MySQLController) GetUserMaxConn(username string) (int, error) {
+	return c.GetUserMaxConnContext(context.Background(), username)
+}
+
+func (c *MySQLController) GetUserMaxConnContext(ctx context.Context, username string) (int, error) {
 	err := validateUsername(username)
 	if err != nil {
 		return 0, err
 	}
 
-	rows, err := c.db.Query("SELECT MAX_USER_CONNECTIONS FROM mysql.user WHERE User = '" + username + "'")
+	rows, err := c.db.QueryContext(ctx, "SELECT MAX_USER_CONNECTIONS FROM mysql.user WHERE User = ? AND Host = ?", username, DefaultHost)
 	if err != nil {
-		return 0, err
+		return 0, classifyError(err, nil)
 	}
 	defer rows.Close()
 
@@ -79,97 +217,546 @@ func (c *MySQLController) GetUserMaxConn(username string) (int, error) {
 }
 
 func (c *MySQLController) UpdateUserMaxConn(username string, maxConn int) error {
+	return c.UpdateUserMaxConnContext(context.Background(), username, maxConn)
+}
+
+func (c *MySQLController) UpdateUserMaxConnContext(ctx context.Context, username string, maxConn int) error {
 	err := validateUsername(username)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.db.Exec("ALTER USER `" + username + "` WITH MAX_USER_CONNECTIONS " + fmt.Sprintf("%d", maxConn))
+	return c.alterAccount(ctx, NewAccount(username), fmt.Sprintf("WITH MAX_USER_CONNECTIONS %d", maxConn))
+}
+
+// UpdateUserResourceLimits replaces all resource limits of the given user.
+func (c *MySQLController) UpdateUserResourceLimits(username string, limits ResourceLimits) error {
+	return c.UpdateUserResourceLimitsContext(context.Background(), username, limits)
+}
+
+// UpdateUserResourceLimitsContext replaces all resource limits of the given user.
+func (c *MySQLController) UpdateUserResourceLimitsContext(ctx context.Context, username string, limits ResourceLimits) error {
+	return c.UpdateAccountResourceLimitsContext(ctx, NewAccount(username), limits)
+}
+
+// UpdateAccountResourceLimits replaces all resource limits of the given account.
+func (c *MySQLController) UpdateAccountResourceLimits(account Account, limits ResourceLimits) error {
+	return c.UpdateAccountResourceLimitsContext(context.Background(), account, limits)
+}
+
+// UpdateAccountResourceLimitsContext replaces all resource limits of the given
+// account in a single statement.
+func (c *MySQLController) UpdateAccountResourceLimitsContext(ctx context.Context, account Account, limits ResourceLimits) error {
+	err := validateAccount(account)
+	if err != nil {
+		return err
+	}
+
+	err = limits.validate()
 	if err != nil {
-		if strings.Contains(err.Error(), "Error 1396") {
-			return ErrUserDoesNotExist
-		}
+		return err
 	}
-	return err
+
+	return c.alterAccount(ctx, account, limits.clause())
 }
 
-func (c *MySQLController) UpdateUserPassword(username, password string) error {
-	err := validateUsername(username)
+// GetUserResourceLimits returns the resource limits of the given user.
+func (c *MySQLController) GetUserResourceLimits(username string) (ResourceLimits, error) {
+	return c.GetUserResourceLimitsContext(context.Background(), username)
+}
+
+// GetUserResourceLimitsContext returns the resource limits of the given user.
+func (c *MySQLController) GetUserResourceLimitsContext(ctx context.Context, username string) (ResourceLimits, error) {
+	return c.GetAccountResourceLimitsContext(ctx, NewAccount(username))
+}
+
+// GetAccountResourceLimits returns the resource limits of the given account.
+func (c *MySQLController) GetAccountResourceLimits(account Account) (ResourceLimits, error) {
+	return c.GetAccountResourceLimitsContext(context.Background(), account)
+}
+
+// GetAccountResourceLimitsContext returns the resource limits of the given account.
+func (c *MySQLController) GetAccountResourceLimitsContext(ctx context.Context, account Account) (ResourceLimits, error) {
+	err := validateAccount(account)
+	if err != nil {
+		return ResourceLimits{}, err
+	}
+
+	var limits ResourceLimits
+	err = c.db.QueryRowContext(ctx, "SELECT max_questions, max_updates, max_connections, max_user_connections FROM mysql.user WHERE user = ? AND host = ?", account.User, account.Host).
+		Scan(&limits.MaxQueriesPerHour, &limits.MaxUpdatesPerHour, &limits.MaxConnectionsPerHour, &limits.MaxUserConnections)
+	if err == sql.ErrNoRows {
+		return ResourceLimits{}, ErrUserDoesNotExist
+	}
+	if err != nil {
+		return ResourceLimits{}, classifyError(err, nil)
+	}
+	return limits, nil
+}
+
+// LockUser locks the given user (ACCOUNT LOCK).
+func (c *MySQLController) LockUser(username string) error {
+	return c.LockUserContext(context.Background(), username)
+}
+
+// LockUserContext locks the given user (ACCOUNT LOCK).
+func (c *MySQLController) LockUserContext(ctx context.Context, username string) error {
+	return c.LockAccountContext(ctx, NewAccount(username))
+}
+
+// LockAccount locks the given account (ACCOUNT LOCK).
+func (c *MySQLController) LockAccount(account Account) error {
+	return c.LockAccountContext(context.Background(), account)
+}
+
+// LockAccountContext locks the given account (ACCOUNT LOCK).
+func (c *MySQLController) LockAccountContext(ctx context.Context, account Account) error {
+	err := validateAccount(account)
 	if err != nil {
 		return err
 	}
 
-	err = validatePassword(password)
+	return c.alterAccount(ctx, account, "ACCOUNT LOCK")
+}
+
+// UnlockUser unlocks the given user (ACCOUNT UNLOCK).
+func (c *MySQLController) UnlockUser(username string) error {
+	return c.UnlockUserContext(context.Background(), username)
+}
+
+// UnlockUserContext unlocks the given user (ACCOUNT UNLOCK).
+func (c *MySQLController) UnlockUserContext(ctx context.Context, username string) error {
+	return c.UnlockAccountContext(ctx, NewAccount(username))
+}
+
+// UnlockAccount unlocks the given account (ACCOUNT UNLOCK).
+func (c *MySQLController) UnlockAccount(account Account) error {
+	return c.UnlockAccountContext(context.Background(), account)
+}
+
+// UnlockAccountContext unlocks the given account (ACCOUNT UNLOCK).
+func (c *MySQLController) UnlockAccountContext(ctx context.Context, account Account) error {
+	err := validateAccount(account)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.db.Exec("SET PASSWORD FOR `" + username + "` = '" + password + "'")
+	return c.alterAccount(ctx, account, "ACCOUNT UNLOCK")
+}
+
+// SetUserPasswordExpiry sets the password expiration policy of the given user.
+func (c *MySQLController) SetUserPasswordExpiry(username string, expiry PasswordExpiry) error {
+	return c.SetUserPasswordExpiryContext(context.Background(), username, expiry)
+}
+
+// SetUserPasswordExpiryContext sets the password expiration policy of the given user.
+func (c *MySQLController) SetUserPasswordExpiryContext(ctx context.Context, username string, expiry PasswordExpiry) error {
+	return c.SetAccountPasswordExpiryContext(ctx, NewAccount(username), expiry)
+}
+
+// SetAccountPasswordExpiry sets the password expiration policy of the given account.
+func (c *MySQLController) SetAccountPasswordExpiry(account Account, expiry PasswordExpiry) error {
+	return c.SetAccountPasswordExpiryContext(context.Background(), account, expiry)
+}
+
+// SetAccountPasswordExpiryContext sets the password expiration policy of the given account.
+func (c *MySQLController) SetAccountPasswordExpiryContext(ctx context.Context, account Account, expiry PasswordExpiry) error {
+	err := validateAccount(account)
 	if err != nil {
-		if strings.Contains(err.Error(), "Error 1133") {
-			return ErrUserDoesNotExist
-		}
+		return err
+	}
+
+	err = expiry.validate()
+	if err != nil {
+		return err
 	}
 
+	return c.alterAccount(ctx, account, expiry.clause())
+}
+
+// SetUserFailedLoginTracking configures temporary locking of the given user
+// after consecutive failed logins.
+func (c *MySQLController) SetUserFailedLoginTracking(username string, f FailedLoginTracking) error {
+	return c.SetUserFailedLoginTrackingContext(context.Background(), username, f)
+}
+
+// SetUserFailedLoginTrackingContext configures temporary locking of the given
+// user after consecutive failed logins.
+func (c *MySQLController) SetUserFailedLoginTrackingContext(ctx context.Context, username string, f FailedLoginTracking) error {
+	return c.SetAccountFailedLoginTrackingContext(ctx, NewAccount(username), f)
+}
+
+// SetAccountFailedLoginTracking configures temporary locking of the given
+// account after consecutive failed logins.
+func (c *MySQLController) SetAccountFailedLoginTracking(account Account, f FailedLoginTracking) error {
+	return c.SetAccountFailedLoginTrackingContext(context.Background(), account, f)
+}
+
+// SetAccountFailedLoginTrackingContext configures temporary locking of the
+// given account after consecutive failed logins.
+func (c *MySQLController) SetAccountFailedLoginTrackingContext(ctx context.Context, account Account, f FailedLoginTracking) error {
+	err := validateAccount(account)
+	if err != nil {
+		return err
+	}
+
+	err = f.validate()
+	if err != nil {
+		return err
+	}
+
+	return c.alterAccount(ctx, account, f.clause())
+}
+
+// GetUserStatus returns the locking and password expiration state of the given user.
+func (c *MySQLController) GetUserStatus(username string) (AccountStatus, error) {
+	return c.GetUserStatusContext(context.Background(), username)
+}
+
+// GetUserStatusContext returns the locking and password expiration state of the given user.
+func (c *MySQLController) GetUserStatusContext(ctx context.Context, username string) (AccountStatus, error) {
+	return c.GetAccountStatusContext(ctx, NewAccount(username))
+}
+
+// GetAccountStatus returns the locking and password expiration state of the given account.
+func (c *MySQLController) GetAccountStatus(account Account) (AccountStatus, error) {
+	return c.GetAccountStatusContext(context.Background(), account)
+}
+
+// GetAccountStatusContext returns the locking and password expiration state of the given account.
+func (c *MySQLController) GetAccountStatusContext(ctx context.Context, account Account) (AccountStatus, error) {
+	err := validateAccount(account)
+	if err != nil {
+		return AccountStatus{}, err
+	}
+
+	var (
+		locked, expired string
+		lastChanged     sql.NullInt64
+		lifetime        sql.NullInt64
+		defaultLifetime int64
+		now             int64
+	)
+	q := "SELECT account_locked, password_expired, UNIX_TIMESTAMP(password_last_changed), password_lifetime," +
+		" @@global.default_password_lifetime, UNIX_TIMESTAMP() FROM mysql.user WHERE user = ? AND host = ?"
+	err = c.db.QueryRowContext(ctx, q, account.User, account.Host).
+		Scan(&locked, &expired, &lastChanged, &lifetime, &defaultLifetime, &now)
+	if err == sql.ErrNoRows {
+		return AccountStatus{}, ErrUserDoesNotExist
+	}
+	if err != nil {
+		return AccountStatus{}, classifyError(err, nil)
+	}
+
+	status := AccountStatus{
+		Locked:          locked == "Y",
+		PasswordExpired: passwordExpired(expired == "Y", lastChanged, lifetime, defaultLifetime, now),
+	}
+	if lastChanged.Valid {
+		status.PasswordLastChanged = time.Unix(lastChanged.Int64, 0)
+	}
+	if lifetime.Valid {
+		days := int(lifetime.Int64)
+		status.PasswordLifetime = &days
+	}
+	return status, nil
+}
+
+// passwordExpired reports whether a password is expired, either explicitly
+// (PASSWORD EXPIRE) or because it is older than its lifetime in days, which
+// falls back to the server default when not set. now is the server time, as
+// a Unix timestamp like lastChanged.
+func passwordExpired(flagged bool, lastChanged, lifetime sql.NullInt64, defaultLifetime, now int64) bool {
+	if flagged {
+		return true
+	}
+
+	days := defaultLifetime
+	if lifetime.Valid {
+		days = lifetime.Int64
+	}
+	if days == 0 || !lastChanged.Valid {
+		return false
+	}
+	return now >= lastChanged.Int64+days*24*60*60
+}
+
+// execQuoted runs the statement built by query on a single connection. query
+// quotes string literals for the SQL mode of that connection, so that they
+// hold exactly the given values with and without NO_BACKSLASH_ESCAPES.
+func (c *MySQLController) execQuoted(ctx context.Context, query func(quote func(string) string) string) error {
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var sqlMode string
+	err = conn.QueryRowContext(ctx, "SELECT @@SESSION.sql_mode").Scan(&sqlMode)
+	if err != nil {
+		return err
+	}
+	noBackslashEscapes := strings.Contains(sqlMode, "NO_BACKSLASH_ESCAPES")
+
+	_, err = conn.ExecContext(ctx, query(func(s string) string {
+		return quoteString(s, noBackslashEscapes)
+	}))
 	return err
 }
 
-func (c *MySQLController) DeleteUser(username string) error {
-	err := validateUsername(username)
+// alterAccount applies the given ALTER USER clause to the account.
+func (c *MySQLController) alterAccount(ctx context.Context, account Account, clause string) error {
+	_, err := c.db.ExecContext(ctx, "ALTER USER "+quoteAccount(account)+" "+clause)
+	return classifyError(err, map[uint16]error{erCannotUser: ErrUserDoesNotExist})
+}
+
+func (c *MySQLController) UpdateUserPassword(username, password string, opts ...UserOption) error {
+	return c.UpdateUserPasswordContext(context.Background(), username, password, opts...)
+}
+
+func (c *MySQLController) UpdateUserPasswordContext(ctx context.Context, username, password string, opts ...UserOption) error {
+	return c.UpdateAccountPasswordContext(ctx, NewAccount(username), password, opts...)
+}
+
+// UpdateAccountPassword sets the password of the given account.
+func (c *MySQLController) UpdateAccountPassword(account Account, password string, opts ...UserOption) error {
+	return c.UpdateAccountPasswordContext(context.Background(), account, password, opts...)
+}
+
+// UpdateAccountPasswordContext sets the password of the given account.
+func (c *MySQLController) UpdateAccountPasswordContext(ctx context.Context, account Account, password string, opts ...UserOption) error {
+	err := validateAccount(account)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.db.Exec(fmt.Sprintf("DROP USER `%s`", username))
+	o := newUserOptions(opts)
+	err = o.validate(password)
 	if err != nil {
-		if strings.Contains(err.Error(), "Error 1396") {
-			return ErrUserDoesNotExist
+		return err
+	}
+
+	if o.needsPassword() {
+		err = validatePassword(password)
+		if err != nil {
+			return err
+		}
+
+		err = c.checkPassword(account, password)
+		if err != nil {
+			return err
 		}
 	}
-	return err
+
+	err = c.execQuoted(ctx, func(quote func(string) string) string {
+		return "ALTER USER " + quoteAccount(account) + " " + o.identifiedClause(password, quote) + o.attributeClauses()
+	})
+	return classifyError(err, map[uint16]error{erCannotUser: ErrUserDoesNotExist, erPasswordNoMatch: ErrUserDoesNotExist})
+}
+
+// BeginUserPasswordRotation sets a new password for the given user while
+// keeping the current one valid, so clients can switch over without downtime.
+func (c *MySQLController) BeginUserPasswordRotation(username, password string) error {
+	return c.BeginUserPasswordRotationContext(context.Background(), username, password)
+}
+
+// BeginUserPasswordRotationContext sets a new password for the given user
+// while keeping the current one valid, so clients can switch over without
+// downtime.
+func (c *MySQLController) BeginUserPasswordRotationContext(ctx context.Context, username, password string) error {
+	return c.BeginAccountPasswordRotationContext(ctx, NewAccount(username), password)
+}
+
+// BeginAccountPasswordRotation sets a new password for the given account
+// while keeping the current one valid (RETAIN CURRENT PASSWORD).
+func (c *MySQLController) BeginAccountPasswordRotation(account Account, password string) error {
+	return c.BeginAccountPasswordRotationContext(context.Background(), account, password)
+}
+
+// BeginAccountPasswordRotationContext sets a new password for the given
+// account while keeping the current one valid (RETAIN CURRENT PASSWORD).
+// Until CompleteAccountPasswordRotationContext is called both passwords are
+// accepted.
+func (c *MySQLController) BeginAccountPasswordRotationContext(ctx context.Context, account Account, password string) error {
+	err := validateAccount(account)
+	if err != nil {
+		return err
+	}
+
+	err = validatePassword(password)
+	if err != nil {
+		return err
+	}
+
+	err = c.checkPassword(account, password)
+	if err != nil {
+		return err
+	}
+
+	err = c.execQuoted(ctx, func(quote func(string) string) string {
+		return "ALTER USER " + quoteAccount(account) + " IDENTIFIED BY " + quote(password) + " RETAIN CURRENT PASSWORD"
+	})
+	return classifyError(err, map[uint16]error{erCannotUser: ErrUserDoesNotExist})
+}
+
+// CompleteUserPasswordRotation invalidates the old password of the given user
+// retained by BeginUserPasswordRotation.
+func (c *MySQLController) CompleteUserPasswordRotation(username string) error {
+	return c.CompleteUserPasswordRotationContext(context.Background(), username)
+}
+
+// CompleteUserPasswordRotationContext invalidates the old password of the
+// given user retained by BeginUserPasswordRotation.
+func (c *MySQLController) CompleteUserPasswordRotationContext(ctx context.Context, username string) error {
+	return c.CompleteAccountPasswordRotationContext(ctx, NewAccount(username))
+}
+
+// CompleteAccountPasswordRotation invalidates the old password of the given
+// account (DISCARD OLD PASSWORD).
+func (c *MySQLController) CompleteAccountPasswordRotation(account Account) error {
+	return c.CompleteAccountPasswordRotationContext(context.Background(), account)
+}
+
+// CompleteAccountPasswordRotationContext invalidates the old password of the
+// given account (DISCARD OLD PASSWORD).
+func (c *MySQLController) CompleteAccountPasswordRotationContext(ctx context.Context, account Account) error {
+	err := validateAccount(account)
+	if err != nil {
+		return err
+	}
+
+	return c.alterAccount(ctx, account, "DISCARD OLD PASSWORD")
+}
+
+func (c *MySQLController) DeleteUser(username string) error {
+	return c.DeleteUserContext(context.Background(), username)
+}
+
+func (c *MySQLController) DeleteUserContext(ctx context.Context, username string) error {
+	return c.DeleteAccountContext(ctx, NewAccount(username))
+}
+
+// DeleteAccount drops the given account.
+func (c *MySQLController) DeleteAccount(account Account) error {
+	return c.DeleteAccountContext(context.Background(), account)
 }
 
+// DeleteAccountContext drops the given account.
+func (c *MySQLController) DeleteAccountContext(ctx context.Context, account Account) error {
+	err := validateAccount(account)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, "DROP USER "+quoteAccount(account))
+	return classifyError(err, map[uint16]error{erCannotUser: ErrUserDoesNotExist})
+}
+
+// ListUsers returns the names of the users with the DefaultHost.
 func (c *MySQLController) ListUsers() ([]string, error) {
-	rows, err := c.db.Query("SELECT user FROM mysql.user WHERE host = '%'")
+	return c.ListUsersContext(context.Background())
+}
+
+// ListUsersContext returns the names of the users with the DefaultHost.
+func (c *MySQLController) ListUsersContext(ctx context.Context) ([]string, error) {
+	accounts, err := c.ListAccountsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+
 	var users []string
+	for _, account := range accounts {
+		if account.Host == DefaultHost {
+			users = append(users, account.User)
+		}
+	}
+	return users, nil
+}
+
+// ListAccounts returns every account on any host.
+func (c *MySQLController) ListAccounts() ([]Account, error) {
+	return c.ListAccountsContext(context.Background())
+}
+
+// ListAccountsContext returns every account on any host.
+func (c *MySQLController) ListAccountsContext(ctx context.Context) ([]Account, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT user, host FROM mysql.user ORDER BY user, host")
+	if err != nil {
+		return nil, classifyError(err, nil)
+	}
+	defer rows.Close()
+	var accounts []Account
 	for rows.Next() {
-		var user string
-		err = rows.Scan(&user)
+		var account Account
+		err = rows.Scan(&account.User, &account.Host)
 		if err != nil {
 			return nil, err
 		}
-		users = append(users, user)
+		accounts = append(accounts, account)
 	}
 
-	return filterUsers(users), nil
+	return filterAccounts(accounts), nil
 }
 
 func (c *MySQLController) UserExists(username string) (bool, error) {
-	err := validateUsername(username)
+	return c.UserExistsContext(context.Background(), username)
+}
+
+func (c *MySQLController) UserExistsContext(ctx context.Context, username string) (bool, error) {
+	return c.AccountExistsContext(ctx, NewAccount(username))
+}
+
+// AccountExists returns true if the given account exists.
+func (c *MySQLController) AccountExists(account Account) (bool, error) {
+	return c.AccountExistsContext(context.Background(), account)
+}
+
+// AccountExistsContext returns true if the given account exists.
+func (c *MySQLController) AccountExistsContext(ctx context.Context, account Account) (bool, error) {
+	err := validateAccount(account)
 	if err != nil {
 		return false, err
 	}
 
 	var exists bool
-	err = c.db.QueryRow("SELECT EXISTS(SELECT 1 FROM mysql.user WHERE user = ? AND host = '%')", username).Scan(&exists)
+	err = c.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM mysql.user WHERE user = ? AND host = ?)", account.User, account.Host).Scan(&exists)
 	if err != nil {
-		return false, err
+		return false, classifyError(err, nil)
 	}
 	return exists, nil
 }
 
-func filterUsers(users []string) []string {
-	var filtered []string
-	for _, user := range users {
-		if !contains(baseUsers, user) {
-			filtered = append(filtered, user)
-		}
+// UserAuthPlugin returns the authentication plugin of the given user.
+func (c *MySQLController) UserAuthPlugin(username string) (string, error) {
+	return c.UserAuthPluginContext(context.Background(), username)
+}
+
+// UserAuthPluginContext returns the authentication plugin of the given user.
+func (c *MySQLController) UserAuthPluginContext(ctx context.Context, username string) (string, error) {
+	return c.AccountAuthPluginContext(ctx, NewAccount(username))
+}
+
+// AccountAuthPlugin returns the authentication plugin of the given account.
+func (c *MySQLController) AccountAuthPlugin(account Account) (string, error) {
+	return c.AccountAuthPluginContext(context.Background(), account)
+}
+
+// AccountAuthPluginContext returns the authentication plugin of the given account.
+func (c *MySQLController) AccountAuthPluginContext(ctx context.Context, account Account) (string, error) {
+	err := validateAccount(account)
+	if err != nil {
+		return "", err
+	}
+
+	var plugin string
+	err = c.db.QueryRowContext(ctx, "SELECT plugin FROM mysql.user WHERE user = ? AND host = ?", account.User, account.Host).Scan(&plugin)
+	if err == sql.ErrNoRows {
+		return "", ErrUserDoesNotExist
+	}
+	if err != nil {
+		return "", classifyError(err, nil)
 	}
-	return filtered
+	return plugin, nil
 }
 
 func validateUsername(username string) error {
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
@@ -162,3 +163,305 @@ func TestMySQLController_UsersMaxConnHandling(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 2, maxConn)
 }
+
+func TestMySQLController_QuotedPasswords(t *testing.T) {
+	noBackslashEscapes, err := NewMySQLController("root:password@tcp(127.0.0.1:6603)/?sql_mode=%27NO_BACKSLASH_ESCAPES%27")
+	assert.NoError(t, err)
+	defer noBackslashEscapes.Close()
+
+	for _, c := range []*MySQLController{createTestController(), noBackslashEscapes} {
+		for _, password := range []string{"pass'word", `pass\word`, `pass\'word`, "x' OR '1'='1"} {
+			err := c.CreateUser(testUser, password)
+			assert.NoError(t, err)
+
+			err = openMySQL(testUser, password, "")
+			assert.NoError(t, err)
+
+			err = c.UpdateUserPassword(testUser, password+"'")
+			assert.NoError(t, err)
+
+			err = openMySQL(testUser, password+"'", "")
+			assert.NoError(t, err)
+
+			err = c.BeginUserPasswordRotation(testUser, password+`\`)
+			assert.NoError(t, err)
+
+			err = openMySQL(testUser, password+`\`, "")
+			assert.NoError(t, err)
+
+			err = c.DeleteUser(testUser)
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestMySQLController_Accounts(t *testing.T) {
+	c := createTestController()
+	local := Account{User: testUser, Host: "localhost"}
+	subnet := Account{User: testUser, Host: "10.0.%"}
+
+	err := c.CreateAccount(local, testPassword)
+	assert.NoError(t, err)
+	defer c.DeleteAccount(local)
+
+	err = c.CreateAccount(local, testPassword)
+	assert.Equal(t, ErrUserExists, err)
+
+	err = c.CreateAccount(subnet, testPassword)
+	assert.NoError(t, err)
+	defer c.DeleteAccount(subnet)
+
+	exists, err := c.AccountExists(local)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = c.UserExists(testUser)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	accounts, err := c.ListAccounts()
+	assert.NoError(t, err)
+	assert.Equal(t, []Account{subnet, local}, accounts)
+
+	names, err := c.ListUsers()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(names))
+
+	err = c.UpdateAccountPassword(local, testPassword)
+	assert.NoError(t, err)
+
+	err = c.DeleteAccount(subnet)
+	assert.NoError(t, err)
+
+	err = c.DeleteAccount(subnet)
+	assert.Equal(t, ErrUserDoesNotExist, err)
+}
+
+func TestMySQLController_AuthPlugins(t *testing.T) {
+	c := createTestController()
+	err := c.CreateUser(testUser, testPassword, WithAuthPlugin(AuthNativePassword))
+	assert.NoError(t, err)
+	defer c.DeleteUser(testUser)
+
+	plugin, err := c.UserAuthPlugin(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthNativePassword, plugin)
+
+	err = openMySQL(testUser, testPassword, "")
+	assert.NoError(t, err)
+
+	err = c.UpdateUserPassword(testUser, testPassword, WithAuthPlugin(AuthCachingSHA2Password))
+	assert.NoError(t, err)
+
+	plugin, err = c.UserAuthPlugin(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthCachingSHA2Password, plugin)
+
+	// mysql_native_password hash of "test-password"
+	hash := "*882CE3A63CD238FF1EB5E64FD3A006B5C06D8943"
+	err = c.UpdateUserPassword(testUser, "", WithPasswordHash(AuthNativePassword, hash))
+	assert.NoError(t, err)
+
+	err = openMySQL(testUser, testPassword, "")
+	assert.NoError(t, err)
+
+	plugin, err = c.UserAuthPlugin(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthNativePassword, plugin)
+
+	err = c.UpdateUserPassword(testUser, testPassword, WithPasswordHash(AuthNativePassword, hash))
+	assert.Error(t, err)
+
+	_, err = c.UserAuthPlugin("non-existing-user")
+	assert.Equal(t, ErrUserDoesNotExist, err)
+}
+
+func TestMySQLController_ResourceLimits(t *testing.T) {
+	c := createTestController()
+	limits := ResourceLimits{MaxQueriesPerHour: 1000, MaxUpdatesPerHour: 100, MaxConnectionsPerHour: 10, MaxUserConnections: 2}
+	err := c.CreateUser(testUser, testPassword, WithResourceLimits(limits))
+	assert.NoError(t, err)
+	defer c.DeleteUser(testUser)
+
+	got, err := c.GetUserResourceLimits(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, limits, got)
+
+	maxConn, err := c.GetUserMaxConn(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, maxConn)
+
+	limits = ResourceLimits{MaxQueriesPerHour: 5000}
+	err = c.UpdateUserResourceLimits(testUser, limits)
+	assert.NoError(t, err)
+
+	got, err = c.GetUserResourceLimits(testUser)
+	assert.NoError(t, err)
+	assert.Equal(t, limits, got)
+
+	err = c.UpdateUserResourceLimits(testUser, ResourceLimits{MaxUserConnections: -1})
+	assert.Error(t, err)
+
+	err = c.UpdateUserResourceLimits("non-existing-user", limits)
+	assert.Equal(t, ErrUserDoesNotExist, err)
+
+	_, err = c.GetUserResourceLimits("non-existing-user")
+	assert.Equal(t, ErrUserDoesNotExist, err)
+}
+
+func TestMySQLController_PasswordPolicy(t *testing.T) {
+	c := createTestController()
+	WithPasswordPolicy(DefaultPasswordPolicy)(c)
+	strongPassword := "Correct7Horse"
+
+	err := c.CreateUser(testUser, testPassword)
+	assert.ErrorIs(t, err, ErrPasswordPolicy)
+
+	err = c.CreateUserWithMaxConn(testUser, testPassword, 1)
+	assert.ErrorIs(t, err, ErrPasswordPolicy)
+
+	err = c.CreateUser(testUser, strongPassword)
+	assert.NoError(t, err)
+	defer c.DeleteUser(testUser)
+
+	err = c.UpdateUserPassword(testUser, testPassword)
+	assert.ErrorIs(t, err, ErrPasswordPolicy)
+
+	err = c.UpdateUserPassword(testUser, strongPassword+"!")
+	assert.NoError(t, err)
+
+	err = openMySQL(testUser, strongPassword+"!", "")
+	assert.NoError(t, err)
+}
+
+func TestMySQLController_CreateUserWithGeneratedPassword(t *testing.T) {
+	c := createTestController()
+	WithPasswordPolicy(DefaultPasswordPolicy)(c)
+
+	creds, err := c.CreateUserWithGeneratedPassword(testUser)
+	assert.NoError(t, err)
+	defer c.DeleteUser(testUser)
+	assert.Equal(t, NewAccount(testUser), creds.Account)
+
+	err = openMySQL(testUser, creds.Password, "")
+	assert.NoError(t, err)
+
+	_, err = c.CreateUserWithGeneratedPassword(testUser)
+	assert.Equal(t, ErrUserExists, err)
+
+	_, err = c.CreateUserWithGeneratedPassword("")
+	assert.Error(t, err)
+
+	for _, name := range baseUsers {
+		_, err = c.CreateUserWithGeneratedPassword(name)
+		assert.Error(t, err)
+	}
+
+	_, err = c.CreateUserWithGeneratedPassword(testUser+"-socket", WithAuthPlugin(AuthSocket))
+	assert.Error(t, err)
+}
+
+func TestMySQLController_AccountStatus(t *testing.T) {
+	c := createTestController()
+	err := c.CreateUser(testUser, testPassword, WithAccountLock())
+	assert.NoError(t, err)
+	defer c.DeleteUser(testUser)
+
+	status, err := c.GetUserStatus(testUser)
+	assert.NoError(t, err)
+	assert.True(t, status.Locked)
+	assert.False(t, status.PasswordExpired)
+	assert.Nil(t, status.PasswordLifetime)
+	assert.WithinDuration(t, time.Now(), status.PasswordLastChanged, time.Minute)
+
+	err = openMySQL(testUser, testPassword, "")
+	assert.Error(t, err)
+
+	err = c.UnlockUser(testUser)
+	assert.NoError(t, err)
+
+	err = openMySQL(testUser, testPassword, "")
+	assert.NoError(t, err)
+
+	err = c.SetUserPasswordExpiry(testUser, PasswordExpireInterval(90))
+	assert.NoError(t, err)
+
+	status, err = c.GetUserStatus(testUser)
+	assert.NoError(t, err)
+	assert.False(t, status.Locked)
+	if assert.NotNil(t, status.PasswordLifetime) {
+		assert.Equal(t, 90, *status.PasswordLifetime)
+	}
+
+	err = c.SetUserPasswordExpiry(testUser, PasswordExpireNow)
+	assert.NoError(t, err)
+
+	status, err = c.GetUserStatus(testUser)
+	assert.NoError(t, err)
+	assert.True(t, status.PasswordExpired)
+
+	err = c.SetUserFailedLoginTracking(testUser, FailedLoginTracking{Attempts: 3, LockDays: 1})
+	assert.NoError(t, err)
+
+	err = c.LockUser(testUser)
+	assert.NoError(t, err)
+
+	status, err = c.GetUserStatus(testUser)
+	assert.NoError(t, err)
+	assert.True(t, status.Locked)
+
+	err = c.LockUser("non-existing-user")
+	assert.Equal(t, ErrUserDoesNotExist, err)
+
+	_, err = c.GetUserStatus("non-existing-user")
+	assert.Equal(t, ErrUserDoesNotExist, err)
+}
+
+func TestMySQLController_PasswordRotation(t *testing.T) {
+	c := createTestController()
+	newPassword := testPassword + "-new"
+
+	err := c.BeginUserPasswordRotation(testUser, newPassword)
+	assert.Equal(t, ErrUserDoesNotExist, err)
+
+	err = c.CreateUser(testUser, testPassword)
+	assert.NoError(t, err)
+	defer c.DeleteUser(testUser)
+
+	err = c.BeginUserPasswordRotation(testUser, "")
+	assert.Error(t, err)
+
+	err = c.BeginUserPasswordRotation(testUser, newPassword)
+	assert.NoError(t, err)
+
+	err = openMySQL(testUser, testPassword, "")
+	assert.NoError(t, err)
+
+	err = openMySQL(testUser, newPassword, "")
+	assert.NoError(t, err)
+
+	err = c.CompleteUserPasswordRotation(testUser)
+	assert.NoError(t, err)
+
+	err = openMySQL(testUser, testPassword, "")
+	assert.Error(t, err)
+
+	err = openMySQL(testUser, newPassword, "")
+	assert.NoError(t, err)
+}
+
+func Test_passwordExpired(t *testing.T) {
+	const day = 24 * 60 * 60
+	changed := sql.NullInt64{Int64: 1000 * day, Valid: true}
+	days := func(n int64) sql.NullInt64 { return sql.NullInt64{Int64: n, Valid: true} }
+
+	assert.True(t, passwordExpired(true, changed, days(0), 0, 1000*day))
+	assert.False(t, passwordExpired(false, changed, days(30), 0, 1029*day))
+	assert.True(t, passwordExpired(false, changed, days(30), 0, 1030*day))
+	// 0 never expires, even with a server default
+	assert.False(t, passwordExpired(false, changed, days(0), 10, 2000*day))
+	// the server default applies when the account has no lifetime
+	assert.True(t, passwordExpired(false, changed, sql.NullInt64{}, 10, 1010*day))
+	assert.False(t, passwordExpired(false, changed, sql.NullInt64{}, 0, 2000*day))
+	assert.False(t, passwordExpired(false, sql.NullInt64{}, days(1), 0, 2000*day))
+}
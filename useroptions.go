@@ -0,0 +1,252 @@
+package mysqlctl
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Authentication plugins shipped with MySQL.
+const (
+	AuthNativePassword      = "mysql_native_password"
+	AuthCachingSHA2Password = "caching_sha2_password"
+	AuthSHA256Password      = "sha256_password"
+	AuthSocket              = "auth_socket"
+)
+
+// UserOption configures the credentials and attributes of an account when it
+// is created or its password is updated.
+type UserOption func(*userOptions)
+
+type userOptions struct {
+	plugin       string
+	passwordHash *string
+	limits       *ResourceLimits
+	expiry       *PasswordExpiry
+	failedLogins *FailedLoginTracking
+	lock         bool
+}
+
+// ResourceLimits are the per-hour and concurrent usage limits of an account.
+// Zero means no limit.
+type ResourceLimits struct {
+	MaxQueriesPerHour     int
+	MaxUpdatesPerHour     int
+	MaxConnectionsPerHour int
+	MaxUserConnections    int
+}
+
+func (l ResourceLimits) validate() error {
+	if l.MaxQueriesPerHour < 0 || l.MaxUpdatesPerHour < 0 || l.MaxConnectionsPerHour < 0 || l.MaxUserConnections < 0 {
+		return fmt.Errorf("resource limits cannot be negative")
+	}
+	return nil
+}
+
+// clause returns the WITH clause of CREATE USER and ALTER USER setting every
+// limit at once.
+func (l ResourceLimits) clause() string {
+	return fmt.Sprintf("WITH MAX_QUERIES_PER_HOUR %d MAX_UPDATES_PER_HOUR %d MAX_CONNECTIONS_PER_HOUR %d MAX_USER_CONNECTIONS %d",
+		l.MaxQueriesPerHour, l.MaxUpdatesPerHour, l.MaxConnectionsPerHour, l.MaxUserConnections)
+}
+
+// WithAuthPlugin returns a UserOption that authenticates the account with the
+// given plugin instead of the server's default one. The password is ignored
+// for AuthSocket.
+func WithAuthPlugin(plugin string) UserOption {
+	return func(o *userOptions) {
+		o.plugin = plugin
+	}
+}
+
+// WithPasswordHash returns a UserOption that sets an already hashed
+// authentication string for the given plugin (IDENTIFIED WITH plugin AS
+// 'hash'). The plugin is required and the password argument must be empty
+// when it is used.
+// For AuthSocket the hash is the name of the operating system user.
+func WithPasswordHash(plugin, hash string) UserOption {
+	return func(o *userOptions) {
+		o.plugin = plugin
+		o.passwordHash = &hash
+	}
+}
+
+// WithResourceLimits returns a UserOption that sets the resource limits of the
+// account.
+func WithResourceLimits(limits ResourceLimits) UserOption {
+	return func(o *userOptions) {
+		o.limits = &limits
+	}
+}
+
+// PasswordExpiry is the password expiration policy of an account.
+type PasswordExpiry struct {
+	mode string
+	days int
+}
+
+var (
+	// PasswordExpireNow marks the password as expired.
+	PasswordExpireNow = PasswordExpiry{mode: "NOW"}
+	// PasswordExpireNever disables password expiration for the account.
+	PasswordExpireNever = PasswordExpiry{mode: "NEVER"}
+	// PasswordExpireDefault applies the server's default_password_lifetime.
+	PasswordExpireDefault = PasswordExpiry{mode: "DEFAULT"}
+)
+
+// PasswordExpireInterval returns a PasswordExpiry that expires the password
+// every given number of days.
+func PasswordExpireInterval(days int) PasswordExpiry {
+	return PasswordExpiry{mode: "INTERVAL", days: days}
+}
+
+func (e PasswordExpiry) validate() error {
+	switch e.mode {
+	case "NOW", "NEVER", "DEFAULT":
+		return nil
+	case "INTERVAL":
+		if e.days <= 0 {
+			return fmt.Errorf("password expiry interval must be positive")
+		}
+		return nil
+	default:
+		return fmt.Errorf("password expiry is not set")
+	}
+}
+
+func (e PasswordExpiry) clause() string {
+	switch e.mode {
+	case "NOW":
+		return "PASSWORD EXPIRE"
+	case "INTERVAL":
+		return fmt.Sprintf("PASSWORD EXPIRE INTERVAL %d DAY", e.days)
+	default:
+		return "PASSWORD EXPIRE " + e.mode
+	}
+}
+
+// PasswordLockUnbounded keeps an account locked after too many failed logins
+// until it is unlocked.
+const PasswordLockUnbounded = -1
+
+// FailedLoginTracking temporarily locks an account after Attempts
+// consecutive failed logins for LockDays days, or PasswordLockUnbounded.
+// Zero values disable tracking.
+type FailedLoginTracking struct {
+	Attempts int
+	LockDays int
+}
+
+func (f FailedLoginTracking) validate() error {
+	if f.Attempts < 0 || f.LockDays < PasswordLockUnbounded {
+		return fmt.Errorf("failed login tracking values cannot be negative")
+	}
+	return nil
+}
+
+func (f FailedLoginTracking) clause() string {
+	lockTime := fmt.Sprintf("%d", f.LockDays)
+	if f.LockDays == PasswordLockUnbounded {
+		lockTime = "UNBOUNDED"
+	}
+	return fmt.Sprintf("FAILED_LOGIN_ATTEMPTS %d PASSWORD_LOCK_TIME %s", f.Attempts, lockTime)
+}
+
+// WithPasswordExpiry returns a UserOption that sets the password expiration
+// policy of the account.
+func WithPasswordExpiry(expiry PasswordExpiry) UserOption {
+	return func(o *userOptions) {
+		o.expiry = &expiry
+	}
+}
+
+// WithFailedLoginTracking returns a UserOption that locks the account after
+// too many failed logins.
+func WithFailedLoginTracking(f FailedLoginTracking) UserOption {
+	return func(o *userOptions) {
+		o.failedLogins = &f
+	}
+}
+
+// WithAccountLock returns a UserOption that locks the account.
+func WithAccountLock() UserOption {
+	return func(o *userOptions) {
+		o.lock = true
+	}
+}
+
+func newUserOptions(opts []UserOption) userOptions {
+	var o userOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// needsPassword reports whether a plain text password has to be provided.
+func (o userOptions) needsPassword() bool {
+	return o.passwordHash == nil && o.plugin != AuthSocket
+}
+
+var pluginNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+func (o userOptions) validate(password string) error {
+	if o.plugin != "" && !pluginNameRegexp.MatchString(o.plugin) {
+		return fmt.Errorf("invalid authentication plugin %q", o.plugin)
+	}
+	if o.passwordHash != nil && password != "" {
+		return fmt.Errorf("password and password hash cannot be used together")
+	}
+	if o.passwordHash != nil && o.plugin == "" {
+		return fmt.Errorf("password hash requires an authentication plugin")
+	}
+	if o.limits != nil {
+		err := o.limits.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if o.expiry != nil {
+		err := o.expiry.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if o.failedLogins != nil {
+		return o.failedLogins.validate()
+	}
+	return nil
+}
+
+// identifiedClause returns the IDENTIFIED clause of CREATE USER and ALTER
+// USER, quoting the password or hash with quote.
+func (o userOptions) identifiedClause(password string, quote func(string) string) string {
+	switch {
+	case o.passwordHash != nil:
+		return "IDENTIFIED WITH " + o.plugin + " AS " + quote(*o.passwordHash)
+	case o.plugin == AuthSocket:
+		return "IDENTIFIED WITH " + o.plugin
+	case o.plugin != "":
+		return "IDENTIFIED WITH " + o.plugin + " BY " + quote(password)
+	default:
+		return "IDENTIFIED BY " + quote(password)
+	}
+}
+
+// attributeClauses returns the clauses following the IDENTIFIED clause of
+// CREATE USER and ALTER USER, each preceded by a space.
+func (o userOptions) attributeClauses() string {
+	var clauses string
+	if o.limits != nil {
+		clauses += " " + o.limits.clause()
+	}
+	if o.expiry != nil {
+		clauses += " " + o.expiry.clause()
+	}
+	if o.failedLogins != nil {
+		clauses += " " + o.failedLogins.clause()
+	}
+	if o.lock {
+		clauses += " ACCOUNT LOCK"
+	}
+	return clauses
+}
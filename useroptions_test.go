@@ -0,0 +1,72 @@
+package mysqlctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userOptions_identifiedClause(t *testing.T) {
+	quote := func(s string) string { return quoteString(s, false) }
+
+	o := newUserOptions(nil)
+	assert.NoError(t, o.validate("pass'word"))
+	assert.True(t, o.needsPassword())
+	assert.Equal(t, "IDENTIFIED BY 'pass''word'", o.identifiedClause("pass'word", quote))
+
+	o = newUserOptions([]UserOption{WithAuthPlugin(AuthNativePassword)})
+	assert.NoError(t, o.validate(testPassword))
+	assert.Equal(t, "IDENTIFIED WITH mysql_native_password BY 'test-password'", o.identifiedClause(testPassword, quote))
+
+	o = newUserOptions([]UserOption{WithAuthPlugin(AuthSocket)})
+	assert.False(t, o.needsPassword())
+	assert.Equal(t, "IDENTIFIED WITH auth_socket", o.identifiedClause("", quote))
+
+	o = newUserOptions([]UserOption{WithPasswordHash(AuthSocket, "deploy")})
+	assert.Equal(t, "IDENTIFIED WITH auth_socket AS 'deploy'", o.identifiedClause("", quote))
+
+	hash := "*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19"
+	o = newUserOptions([]UserOption{WithPasswordHash(AuthNativePassword, hash)})
+	assert.False(t, o.needsPassword())
+	assert.NoError(t, o.validate(""))
+	assert.Error(t, o.validate(testPassword))
+	assert.Equal(t, "IDENTIFIED WITH mysql_native_password AS '"+hash+"'", o.identifiedClause("", quote))
+
+	o = newUserOptions([]UserOption{WithPasswordHash("", hash)})
+	assert.Error(t, o.validate(""))
+
+	o = newUserOptions([]UserOption{WithAuthPlugin("mysql_native_password BY ''; --")})
+	assert.Error(t, o.validate(testPassword))
+}
+
+func Test_userOptions_attributeClauses(t *testing.T) {
+	o := newUserOptions(nil)
+	assert.Equal(t, "", o.attributeClauses())
+
+	o = newUserOptions([]UserOption{WithResourceLimits(ResourceLimits{MaxQueriesPerHour: 1000, MaxUserConnections: 5})})
+	assert.NoError(t, o.validate(testPassword))
+	assert.Equal(t, " WITH MAX_QUERIES_PER_HOUR 1000 MAX_UPDATES_PER_HOUR 0 MAX_CONNECTIONS_PER_HOUR 0 MAX_USER_CONNECTIONS 5", o.attributeClauses())
+
+	o = newUserOptions([]UserOption{WithResourceLimits(ResourceLimits{MaxUpdatesPerHour: -1})})
+	assert.Error(t, o.validate(testPassword))
+}
+
+func Test_userOptions_passwordOptions(t *testing.T) {
+	o := newUserOptions([]UserOption{
+		WithPasswordExpiry(PasswordExpireInterval(90)),
+		WithFailedLoginTracking(FailedLoginTracking{Attempts: 3, LockDays: PasswordLockUnbounded}),
+		WithAccountLock(),
+	})
+	assert.NoError(t, o.validate(testPassword))
+	assert.Equal(t, " PASSWORD EXPIRE INTERVAL 90 DAY FAILED_LOGIN_ATTEMPTS 3 PASSWORD_LOCK_TIME UNBOUNDED ACCOUNT LOCK", o.attributeClauses())
+
+	assert.Equal(t, "PASSWORD EXPIRE", PasswordExpireNow.clause())
+	assert.Equal(t, "PASSWORD EXPIRE NEVER", PasswordExpireNever.clause())
+	assert.Equal(t, "PASSWORD EXPIRE DEFAULT", PasswordExpireDefault.clause())
+	assert.Equal(t, "FAILED_LOGIN_ATTEMPTS 5 PASSWORD_LOCK_TIME 2", FailedLoginTracking{Attempts: 5, LockDays: 2}.clause())
+
+	assert.Error(t, PasswordExpiry{}.validate())
+	assert.Error(t, PasswordExpireInterval(0).validate())
+	assert.Error(t, FailedLoginTracking{Attempts: -1}.validate())
+	assert.Error(t, FailedLoginTracking{LockDays: -2}.validate())
+}